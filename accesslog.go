@@ -0,0 +1,196 @@
+package zlogger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+access log formats of the gin loggers
+JSON_FORMAT and CONSOLE_FORMAT are the structured / colored entries of the
+JSON_LOGGER / DEBUG_LOGGER, the others are written as plain lines
+every format is built from the same gin.LogFormatterParams
+*/
+
+type AccessLogFormat string
+
+const (
+	// JSON_FORMAT logs structured entries (default of JSON_LOGGER)
+	JSON_FORMAT AccessLogFormat = "json"
+	// CONSOLE_FORMAT logs colored lines (default of DEBUG_LOGGER)
+	CONSOLE_FORMAT AccessLogFormat = "console"
+	// COMMON_FORMAT logs NCSA Common Log Format lines
+	COMMON_FORMAT AccessLogFormat = "common"
+	// COMBINED_FORMAT logs Apache Combined Log Format lines
+	COMBINED_FORMAT AccessLogFormat = "combined"
+	// W3C_FORMAT logs W3C Extended Log File Format lines, see WithW3CFields
+	W3C_FORMAT AccessLogFormat = "w3c"
+)
+
+// W3C fields logged by default
+var defaultW3CFields = []string{"date", "time", "c-ip", "cs-method", "cs-uri-stem", "cs-uri-query", "sc-status", "sc-bytes", "time-taken"}
+
+// encodes the message only, access log lines are written as they are
+func plainLineConfig(loggerConfig LoggerConfig) LoggerConfig {
+	loggerConfig.config.Encoding = "console"
+	loggerConfig.config.EncoderConfig = zapcore.EncoderConfig{
+		MessageKey: "message",
+		LineEnding: zapcore.DefaultLineEnding,
+	}
+	loggerConfig.config.InitialFields = nil
+	return loggerConfig
+}
+
+// CommonLogFormatter formats params as a NCSA Common Log Format line,
+// it can be used with gin.LoggerWithConfig as well
+func CommonLogFormatter(params gin.LogFormatterParams) string {
+	return commonLogLine(params) + "\n"
+}
+
+// CombinedLogFormatter formats params as an Apache Combined Log Format line,
+// it can be used with gin.LoggerWithConfig as well
+func CombinedLogFormatter(params gin.LogFormatterParams) string {
+	return combinedLogLine(params) + "\n"
+}
+
+// W3CLogFormatter returns a formatter of W3C Extended Log File Format lines
+// with fields (date, time, c-ip, cs-method, cs-uri-stem, cs-uri-query,
+// sc-status, sc-bytes, cs-bytes, time-taken, cs-username, cs(Header) ...),
+// the default ones when empty
+func W3CLogFormatter(fields ...string) gin.LogFormatter {
+	if len(fields) == 0 {
+		fields = defaultW3CFields
+	}
+	return func(params gin.LogFormatterParams) string {
+		return w3cLogLine(params, fields) + "\n"
+	}
+}
+
+// W3CLogDirectives returns the #Version and #Fields lines heading a W3C log
+func W3CLogDirectives(fields ...string) string {
+	if len(fields) == 0 {
+		fields = defaultW3CFields
+	}
+	return "#Version: 1.0\n#Fields: " + strings.Join(fields, " ") + "\n"
+}
+
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func accessLogUser(params gin.LogFormatterParams) string {
+	if params.Request == nil {
+		return ""
+	}
+	if user, _, ok := params.Request.BasicAuth(); ok {
+		return user
+	}
+	return ""
+}
+
+func requestHeader(params gin.LogFormatterParams, name string) string {
+	if params.Request == nil {
+		return ""
+	}
+	return params.Request.Header.Get(name)
+}
+
+// host ident user [time] "request" status bytes
+func commonLogLine(params gin.LogFormatterParams) string {
+	var proto string = "HTTP/1.1"
+	if params.Request != nil {
+		proto = params.Request.Proto
+	}
+	var bytes string = "-"
+	if params.BodySize > 0 {
+		bytes = strconv.Itoa(params.BodySize)
+	}
+	return fmt.Sprintf("%s - %s [%s] %q %d %s",
+		dashIfEmpty(params.ClientIP),
+		dashIfEmpty(accessLogUser(params)),
+		params.TimeStamp.Format("02/Jan/2006:15:04:05 -0700"),
+		params.Method+" "+params.Path+" "+proto,
+		params.StatusCode,
+		bytes,
+	)
+}
+
+// common + "referer" "user agent"
+func combinedLogLine(params gin.LogFormatterParams) string {
+	return fmt.Sprintf("%s %q %q",
+		commonLogLine(params),
+		dashIfEmpty(requestHeader(params, "Referer")),
+		dashIfEmpty(requestHeader(params, "User-Agent")),
+	)
+}
+
+func w3cLogLine(params gin.LogFormatterParams, fields []string) string {
+	path, query, _ := strings.Cut(params.Path, "?")
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		var value string
+		switch field {
+		case "date":
+			value = params.TimeStamp.UTC().Format("2006-01-02")
+		case "time":
+			value = params.TimeStamp.UTC().Format("15:04:05")
+		case "c-ip":
+			value = params.ClientIP
+		case "cs-method":
+			value = params.Method
+		case "cs-uri-stem":
+			value = path
+		case "cs-uri-query":
+			value = query
+		case "cs-uri":
+			value = params.Path
+		case "sc-status":
+			value = strconv.Itoa(params.StatusCode)
+		case "sc-bytes":
+			if params.BodySize >= 0 {
+				value = strconv.Itoa(params.BodySize)
+			}
+		case "cs-bytes":
+			if params.Request != nil && params.Request.ContentLength >= 0 {
+				value = strconv.FormatInt(params.Request.ContentLength, 10)
+			}
+		case "time-taken":
+			value = strconv.FormatFloat(params.Latency.Seconds(), 'f', 3, 64)
+		case "cs-username":
+			value = accessLogUser(params)
+		default:
+			// cs(User-Agent), cs(Referer) ...
+			if strings.HasPrefix(field, "cs(") && strings.HasSuffix(field, ")") {
+				value = requestHeader(params, field[3:len(field)-1])
+			}
+		}
+		// fields are separated by spaces
+		values[i] = dashIfEmpty(strings.ReplaceAll(value, " ", "+"))
+	}
+	return strings.Join(values, " ")
+}
+
+// line formatter of the plain formats, nil for the structured ones
+func plainLineFormatter(format AccessLogFormat, w3cFields []string) func(params gin.LogFormatterParams) string {
+	switch format {
+	case COMMON_FORMAT:
+		return commonLogLine
+	case COMBINED_FORMAT:
+		return combinedLogLine
+	case W3C_FORMAT:
+		if len(w3cFields) == 0 {
+			w3cFields = defaultW3CFields
+		}
+		return func(params gin.LogFormatterParams) string {
+			return w3cLogLine(params, w3cFields)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package zlogger
 
 import (
+	"context"
 	"fmt"
 
 	"go.uber.org/zap"
@@ -8,11 +9,10 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 )
 
-var _appLogger AppLogger
-
-
 type appLogger struct {
 	*zap.Logger
+	// keys of the fields bound by With, not added again from the ctx by the *Ctx methods
+	boundKeys map[string]struct{}
 }
 
 // Logger is a logger that supports log levels, context and structured logging.
@@ -34,26 +34,124 @@ type AppLogger interface {
 	Warnf(template string, args ...interface{})
 	// Error uses fmt.Sprint to construct and log a message at ERROR level
 	Errorf(template string, args ...interface{})
+
+	// DebugCtx logs a message at DEBUG level with the fields carried by ctx
+	DebugCtx(ctx context.Context, msg string, fields ...zapcore.Field)
+	// InfoCtx logs a message at INFO level with the fields carried by ctx
+	InfoCtx(ctx context.Context, msg string, fields ...zapcore.Field)
+	// WarnCtx logs a message at WARN level with the fields carried by ctx
+	WarnCtx(ctx context.Context, msg string, fields ...zapcore.Field)
+	// ErrorCtx logs a message at ERROR level with the fields carried by ctx
+	ErrorCtx(ctx context.Context, msg string, fields ...zapcore.Field)
+
+	// DebugCtxf uses fmt.Sprintf to log a message at DEBUG level with the fields carried by ctx
+	DebugCtxf(ctx context.Context, template string, args ...interface{})
+	// InfoCtxf uses fmt.Sprintf to log a message at INFO level with the fields carried by ctx
+	InfoCtxf(ctx context.Context, template string, args ...interface{})
+	// WarnCtxf uses fmt.Sprintf to log a message at WARN level with the fields carried by ctx
+	WarnCtxf(ctx context.Context, template string, args ...interface{})
+	// ErrorCtxf uses fmt.Sprintf to log a message at ERROR level with the fields carried by ctx
+	ErrorCtxf(ctx context.Context, template string, args ...interface{})
+
+	// With returns a child logger that adds fields to every entry
+	With(fields ...zapcore.Field) AppLogger
+	// Named returns a child logger with name appended to the logger name,
+	// separated by a "." like the names built by CreateLoggerName
+	Named(name string) AppLogger
+
+	// Sync flushes the buffered entries of the logger, async ones included
+	Sync() error
 }
 
 func (l *appLogger) Debugf(template string, args ...interface{}) {
 	debugString := fmt.Sprintf(template, args...)
-	l.Named("app").Debug(debugString)
+	l.Logger.Debug(debugString)
 }
 
 func (l *appLogger) Infof(template string, args ...interface{}) {
 	infoString := fmt.Sprintf(template, args...)
-	l.Named("app").Info(infoString)
+	l.Logger.Info(infoString)
 }
 
 func (l *appLogger) Warnf(template string, args ...interface{}) {
 	warnString := fmt.Sprintf(template, args...)
-	l.Named("app").Warn(warnString)
+	l.Logger.Warn(warnString)
 }
 
 func (l *appLogger) Errorf(template string, args ...interface{}) {
 	errorString := fmt.Sprintf(template, args...)
-	l.Named("app").Error(errorString)
+	l.Logger.Error(errorString)
+}
+
+func (l *appLogger) With(fields ...zapcore.Field) AppLogger {
+	boundKeys := make(map[string]struct{}, len(l.boundKeys)+len(fields))
+	for key := range l.boundKeys {
+		boundKeys[key] = struct{}{}
+	}
+	for _, field := range fields {
+		boundKeys[field.Key] = struct{}{}
+	}
+	return &appLogger{Logger: l.Logger.With(fields...), boundKeys: boundKeys}
+}
+
+func (l *appLogger) Named(name string) AppLogger {
+	return &appLogger{Logger: l.Logger.Named(name), boundKeys: l.boundKeys}
+}
+
+// the fields of ctx, without the ones already bound to the logger
+// (a request logger carries the request id of its ctx)
+func (l *appLogger) contextFields(ctx context.Context) []zapcore.Field {
+	ctxFields := FieldsFromContext(ctx)
+	if len(l.boundKeys) == 0 {
+		return ctxFields
+	}
+	unbound := ctxFields[:0]
+	for _, field := range ctxFields {
+		if _, ok := l.boundKeys[field.Key]; !ok {
+			unbound = append(unbound, field)
+		}
+	}
+	return unbound
+}
+
+func (l *appLogger) withContextFields(ctx context.Context, fields []zapcore.Field) []zapcore.Field {
+	ctxFields := l.contextFields(ctx)
+	if len(ctxFields) == 0 {
+		return fields
+	}
+	return append(ctxFields, fields...)
+}
+
+func (l *appLogger) DebugCtx(ctx context.Context, msg string, fields ...zapcore.Field) {
+	l.Logger.Debug(msg, l.withContextFields(ctx, fields)...)
+}
+
+func (l *appLogger) InfoCtx(ctx context.Context, msg string, fields ...zapcore.Field) {
+	l.Logger.Info(msg, l.withContextFields(ctx, fields)...)
+}
+
+func (l *appLogger) WarnCtx(ctx context.Context, msg string, fields ...zapcore.Field) {
+	l.Logger.Warn(msg, l.withContextFields(ctx, fields)...)
+}
+
+func (l *appLogger) ErrorCtx(ctx context.Context, msg string, fields ...zapcore.Field) {
+	l.Logger.Error(msg, l.withContextFields(ctx, fields)...)
+}
+
+func (l *appLogger) DebugCtxf(ctx context.Context, template string, args ...interface{}) {
+	l.Logger.Debug(fmt.Sprintf(template, args...), l.contextFields(ctx)...)
+}
+
+func (l *appLogger) InfoCtxf(ctx context.Context, template string, args ...interface{}) {
+	l.Logger.Info(fmt.Sprintf(template, args...), l.contextFields(ctx)...)
+}
+
+func (l *appLogger) WarnCtxf(ctx context.Context, template string, args ...interface{}) {
+	l.Logger.Warn(fmt.Sprintf(template, args...), l.contextFields(ctx)...)
+}
+
+func (l *appLogger) ErrorCtxf(ctx context.Context, template string, args ...interface{}) {
+	l.Logger.Error(fmt.Sprintf(template, args...), l.contextFields(ctx)...)
 }
 
 // NewZloggerForTest returns a new logger and the corresponding observed logs which can be used in unit tests to verify log entries.
@@ -65,21 +163,37 @@ func NewAppLoggerForTest() (AppLogger, *observer.ObservedLogs) {
 	testCore, recorded = observer.New(zapcore.InfoLevel)
 
 	testLogger = zap.New(testCore)
-	return &appLogger{testLogger}, recorded
+	return &appLogger{Logger: testLogger}, recorded
 }
 
 /*
 * loggerConfig.loggerType - debug / json
 * loggerName - name of the logger ("app" :default) 
 */
-func NewAppLogger(loggerConfig loggerConfig) (AppLogger){
-	_libLogger := generateZapLogger(&loggerConfig.config, "lib")
-	_appLogger = &appLogger{generateZapLogger(&loggerConfig.config, loggerConfig.loggerName)}
+func NewAppLogger(loggerConfig LoggerConfig) (AppLogger, error){
+	_libLogger, err := generateZapLogger(&loggerConfig, "lib")
+	if err != nil {
+		return nil, &ConfigError{Logger: "lib", LoggerName: loggerConfig.loggerName, Err: err}
+	}
+	_zapLogger, err := generateZapLogger(&loggerConfig, loggerConfig.loggerName)
+	if err != nil {
+		return nil, &ConfigError{Logger: APP_LOGGER, LoggerName: loggerConfig.loggerName, Err: err}
+	}
+	_appLogger := &appLogger{Logger: _zapLogger}
 
 	if loggerConfig.loggerType == DEBUG_LOGGER {
 		_libLogger.Info("created a [DEBUG-APP-LOGGER] with logger-name :: " + loggerConfig.loggerName)
 	} else if loggerConfig.loggerType == JSON_LOGGER {
 		_libLogger.Info("created a [JSON-APP-LOGGER] with logger-name :: " + loggerConfig.loggerName)
 	}
+	return _appLogger, nil
+}
+
+// MustNewAppLogger is like NewAppLogger but panics if the logger can't be built
+func MustNewAppLogger(loggerConfig LoggerConfig) AppLogger {
+	_appLogger, err := NewAppLogger(loggerConfig)
+	if err != nil {
+		panic(err)
+	}
 	return _appLogger
 }
\ No newline at end of file
@@ -0,0 +1,233 @@
+package zlogger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+opt-in asynchronous writes
+entries are encoded by the caller, queued in a bounded ring buffer and
+written by a background goroutine every FlushInterval or once BatchSize
+entries are queued
+Sync drains the queue before syncing the outputs
+*/
+
+type DropPolicy string
+
+const (
+	// DROP_OLDEST overwrites the oldest queued entry when the buffer is full
+	DROP_OLDEST DropPolicy = "dropOldest"
+	// BLOCK makes the caller wait for free space when the buffer is full
+	BLOCK DropPolicy = "block"
+)
+
+type AsyncConfig struct {
+	// BufferSize is the number of entries the ring buffer holds (default 4096)
+	BufferSize int
+	// BatchSize wakes the writer once this many entries are queued (default 256)
+	BatchSize int
+	// FlushInterval is the longest an entry waits in the buffer (default 100ms)
+	FlushInterval time.Duration
+	// DropPolicy decides what happens when the buffer is full (default DROP_OLDEST)
+	DropPolicy DropPolicy
+}
+
+func (ac AsyncConfig) withDefaults() AsyncConfig {
+	if ac.BufferSize <= 0 {
+		ac.BufferSize = 4096
+	}
+	if ac.BatchSize <= 0 {
+		ac.BatchSize = 256
+	}
+	if ac.BatchSize > ac.BufferSize {
+		ac.BatchSize = ac.BufferSize
+	}
+	if ac.FlushInterval <= 0 {
+		ac.FlushInterval = 100 * time.Millisecond
+	}
+	if ac.DropPolicy != BLOCK {
+		ac.DropPolicy = DROP_OLDEST
+	}
+	return ac
+}
+
+var _asyncDropped atomic.Uint64
+
+// AsyncDroppedEntries returns the number of entries dropped by every
+// async logger because their buffer was full.
+func AsyncDroppedEntries() uint64 {
+	return _asyncDropped.Load()
+}
+
+var _asyncBuffers = buffer.NewPool()
+
+// an encoded entry and the output it is written to
+type asyncEntry struct {
+	out zapcore.WriteSyncer
+	buf *buffer.Buffer
+}
+
+type asyncQueue struct {
+	config AsyncConfig
+
+	mu      sync.Mutex
+	notFull *sync.Cond
+	buffer  []asyncEntry
+	head    int
+	size    int
+	closed  bool
+
+	// serializes writes of the worker and Sync, keeping entries in order
+	writeMu sync.Mutex
+	batch   []asyncEntry
+
+	wakeup  chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newAsyncQueue(asyncConfig AsyncConfig) *asyncQueue {
+	asyncConfig = asyncConfig.withDefaults()
+	q := &asyncQueue{
+		config:  asyncConfig,
+		buffer:  make([]asyncEntry, asyncConfig.BufferSize),
+		batch:   make([]asyncEntry, 0, asyncConfig.BufferSize),
+		wakeup:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	q.notFull = sync.NewCond(&q.mu)
+	go q.run()
+	return q
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.stopped)
+	ticker := time.NewTicker(q.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-q.wakeup:
+		case <-q.done:
+			q.drain()
+			return
+		}
+		q.drain()
+	}
+}
+
+func (q *asyncQueue) push(e asyncEntry) {
+	q.mu.Lock()
+	for !q.closed && q.size == len(q.buffer) {
+		if q.config.DropPolicy == BLOCK {
+			q.notFull.Wait()
+			continue
+		}
+		q.buffer[q.head].buf.Free()
+		q.buffer[q.head] = asyncEntry{}
+		q.head = (q.head + 1) % len(q.buffer)
+		q.size--
+		_asyncDropped.Add(1)
+	}
+	if q.closed {
+		q.mu.Unlock()
+		// nothing drains a closed queue anymore
+		e.out.Write(e.buf.Bytes())
+		e.buf.Free()
+		return
+	}
+	q.buffer[(q.head+q.size)%len(q.buffer)] = e
+	q.size++
+	wakeup := q.size >= q.config.BatchSize
+	q.mu.Unlock()
+
+	if wakeup {
+		select {
+		case q.wakeup <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// drain writes every queued entry
+func (q *asyncQueue) drain() error {
+	q.writeMu.Lock()
+	defer q.writeMu.Unlock()
+
+	var err error
+	for {
+		q.mu.Lock()
+		if q.size == 0 {
+			q.mu.Unlock()
+			return err
+		}
+		for i := 0; i < q.size; i++ {
+			idx := (q.head + i) % len(q.buffer)
+			q.batch = append(q.batch, q.buffer[idx])
+			q.buffer[idx] = asyncEntry{}
+		}
+		q.head, q.size = 0, 0
+		q.notFull.Broadcast()
+		q.mu.Unlock()
+
+		for i := range q.batch {
+			if _, writeErr := q.batch[i].out.Write(q.batch[i].buf.Bytes()); writeErr != nil && err == nil {
+				err = writeErr
+			}
+			q.batch[i].buf.Free()
+			q.batch[i] = asyncEntry{}
+		}
+		q.batch = q.batch[:0]
+	}
+}
+
+// close stops the worker after writing every queued entry
+func (q *asyncQueue) close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		<-q.stopped
+		return
+	}
+	q.closed = true
+	q.notFull.Broadcast()
+	q.mu.Unlock()
+
+	close(q.done)
+	<-q.stopped
+}
+
+// asyncWriter queues the entries encoded by the core writing to it,
+// the caller may reuse or change the logged values once Write returns
+type asyncWriter struct {
+	out   zapcore.WriteSyncer
+	queue *asyncQueue
+}
+
+func newAsyncWriter(out zapcore.WriteSyncer, queue *asyncQueue) zapcore.WriteSyncer {
+	return &asyncWriter{out: out, queue: queue}
+}
+
+// Write is called once per entry, with a buffer freed once it returns
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	buf := _asyncBuffers.Get()
+	buf.Write(p)
+	w.queue.push(asyncEntry{out: w.out, buf: buf})
+	return len(p), nil
+}
+
+// Sync is also called by the core after Panic and Fatal entries,
+// the process may exit right after
+func (w *asyncWriter) Sync() error {
+	err := w.queue.drain()
+	if syncErr := w.out.Sync(); syncErr != nil {
+		err = syncErr
+	}
+	return err
+}
@@ -159,9 +159,14 @@ func colorifyRequestMethod(methodName string) string {
 }
 
 func colorifyRequestLatency(latency time.Duration) string {
-	if latency < time.Second {
+	return colorifyLatencyBands(latency, time.Second, time.Second*2)
+}
+
+// green below yellowFrom, yellow below redFrom, red above
+func colorifyLatencyBands(latency time.Duration, yellowFrom time.Duration, redFrom time.Duration) string {
+	if latency < yellowFrom {
 		return colorPallet.colorfgGreen(latency.String())
-	} else if latency < time.Second*2 {
+	} else if latency < redFrom {
 		return colorPallet.colorfgYellow(latency.String())
 	}
 	return colorPallet.colorfgRed(latency.String())
@@ -1,51 +1,92 @@
 package zlogger
 
 import (
+	"sync/atomic"
+	"time"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	gormlogger "gorm.io/gorm/logger"
 )
 
-
-type loggerConfig struct {
+// LoggerConfig is built by NewLoggerConfig and shared by the app, gin and gorm loggers
+type LoggerConfig struct {
 	loggerName string
 	loggerType LoggerType
 	loggerLevel zapcore.Level
   config zap.Config
+	levelOverrides *LevelOverrides
+	async *AsyncConfig
+	gorm gormConfig
+	// shared by every copy of the config, identifies the loggers built from it
+	setID uint64
+}
+
+var _configSetID atomic.Uint64
+
+type gormConfig struct {
+	slowThreshold             time.Duration
+	logLevel                  gormlogger.LogLevel
+	ignoreRecordNotFoundError bool
 }
 
-func (lc *loggerConfig) GetLoggerName() string {
+func (lc *LoggerConfig) GetLoggerName() string {
 	return lc.loggerName
 }
 
-func (lc *loggerConfig) GetLoggerLevel() zapcore.Level {
+func (lc *LoggerConfig) GetLoggerLevel() zapcore.Level {
 	return lc.loggerLevel
 }
 
-func (lc *loggerConfig) GetLoggerType() LoggerType {
+func (lc *LoggerConfig) GetLoggerType() LoggerType {
 	return lc.loggerType
 }
 
-func (lc *loggerConfig) GetZapConfig() zap.Config {
+func (lc *LoggerConfig) GetZapConfig() zap.Config {
 	return lc.config
 }
 
-func (lc *loggerConfig) SetLoggerName(loggerName string) string {
+func (lc *LoggerConfig) GetLevelOverrides() *LevelOverrides {
+	return lc.levelOverrides
+}
+
+func (lc *LoggerConfig) SetLoggerName(loggerName string) string {
 	lc.loggerName = loggerName
 	return lc.loggerName
 }
 
-func (lc *loggerConfig) SetLoggerLevel(loggerLevel zapcore.Level) zapcore.Level {
+func (lc *LoggerConfig) SetLoggerLevel(loggerLevel zapcore.Level) zapcore.Level {
 	lc.loggerLevel = loggerLevel
 	return lc.loggerLevel
 }
 
-func (lc *loggerConfig) SetLoggerType(loggerType LoggerType) LoggerType {
+func (lc *LoggerConfig) SetLoggerType(loggerType LoggerType) LoggerType {
 	lc.loggerType = loggerType
 	return lc.loggerType
 }
 
+// SetLevelOverrides sets the per-logger-name levels, nil disables them
+func (lc *LoggerConfig) SetLevelOverrides(levelOverrides *LevelOverrides) *LevelOverrides {
+	lc.levelOverrides = levelOverrides
+	return lc.levelOverrides
+}
 
-func NewLoggerConfig(loggerName string, loggerType LoggerType, loggerLevel zapcore.Level) (loggerConfig) {
+// SetFileSink makes the loggers write to a rotating file instead of stderr
+func (lc *LoggerConfig) SetFileSink(fileSinkConfig FileSinkConfig) {
+	lc.config.OutputPaths = []string{fileSinkConfig.URL()}
+}
+
+// SetAsync makes the loggers queue entries and write them in the background
+func (lc *LoggerConfig) SetAsync(asyncConfig AsyncConfig) {
+	lc.async = &asyncConfig
+}
+
+
+/*
+* loggerType - DEBUG_LOGGER / JSON_LOGGER preset
+* options - WithOutputPaths, WithEncoderConfig ... applied on top of the preset
+*/
+func NewLoggerConfig(loggerName string, loggerType LoggerType, loggerLevel zapcore.Level, options ...LoggerOption) (LoggerConfig) {
 	if loggerType != DEBUG_LOGGER && loggerType != JSON_LOGGER {
 		loggerType = DEBUG_LOGGER
 	}
@@ -53,10 +94,16 @@ func NewLoggerConfig(loggerName string, loggerType LoggerType, loggerLevel zapco
 		loggerName = "app"
 	}
 
-	_loggerConfig := loggerConfig{
+	_loggerConfig := LoggerConfig{
+		setID: _configSetID.Add(1),
 		loggerName: loggerName,
 		loggerType: loggerType,
 		loggerLevel: loggerLevel,
+		levelOverrides: NewLevelOverrides(nil),
+		gorm: gormConfig{
+			slowThreshold: 100 * time.Millisecond,
+			logLevel:      gormlogger.Info,
+		},
 		config:  zap.Config{
 			Level:            zap.NewAtomicLevelAt(loggerLevel),
 			Development:      false,
@@ -86,5 +133,8 @@ func NewLoggerConfig(loggerName string, loggerType LoggerType, loggerLevel zapco
 		_loggerConfig.config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 		_loggerConfig.config.EncoderConfig.EncodeDuration = zapcore.StringDurationEncoder
 	}
+	for _, option := range options {
+		option(&_loggerConfig)
+	}
 	return _loggerConfig
 }
\ No newline at end of file
@@ -5,4 +5,11 @@ type LoggerType string
 const (
   DEBUG_LOGGER LoggerType = "debug"
   JSON_LOGGER LoggerType = "json"
-)
\ No newline at end of file
+)
+
+// names of the loggers created by SetupLoggerWithConfig
+const (
+  APP_LOGGER  string = "app"
+  GIN_LOGGER  string = "gin"
+  GORM_LOGGER string = "gorm"
+)
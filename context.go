@@ -0,0 +1,69 @@
+package zlogger
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ContextExtractor pulls log fields (request id, user id, trace id ...)
+// out of a context.Context. It is called for every *Ctx log call.
+type ContextExtractor func(ctx context.Context) []zapcore.Field
+
+type contextFieldsKey struct{}
+
+var (
+	_extractorsMu sync.RWMutex
+	_extractors   []ContextExtractor
+)
+
+// RegisterContextExtractor adds an extractor whose fields are attached
+// to every entry logged via the *Ctx methods of AppLogger.
+func RegisterContextExtractor(extractor ContextExtractor) {
+	if extractor == nil {
+		return
+	}
+	_extractorsMu.Lock()
+	defer _extractorsMu.Unlock()
+	_extractors = append(_extractors, extractor)
+}
+
+// ContextWithFields returns a copy of ctx carrying fields, on top of
+// any fields already stored in ctx.
+func ContextWithFields(ctx context.Context, fields ...zapcore.Field) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	existing, _ := ctx.Value(contextFieldsKey{}).([]zapcore.Field)
+	merged := make([]zapcore.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, contextFieldsKey{}, merged)
+}
+
+// FieldsFromContext returns the fields stored in ctx via ContextWithFields,
+// the trace_id, span_id and trace_flags of the span in ctx, followed by the
+// fields of every registered extractor.
+func FieldsFromContext(ctx context.Context) []zapcore.Field {
+	if ctx == nil {
+		return nil
+	}
+	stored, _ := ctx.Value(contextFieldsKey{}).([]zapcore.Field)
+
+	_extractorsMu.RLock()
+	extractors := _extractors
+	_extractorsMu.RUnlock()
+
+	spanFields := traceFields(ctx)
+	if len(stored) == 0 && len(spanFields) == 0 && len(extractors) == 0 {
+		return nil
+	}
+	fields := make([]zapcore.Field, 0, len(stored)+len(spanFields))
+	fields = append(fields, stored...)
+	fields = append(fields, spanFields...)
+	for _, extractor := range extractors {
+		fields = append(fields, extractor(ctx)...)
+	}
+	return fields
+}
@@ -0,0 +1,21 @@
+package zlogger
+
+import "fmt"
+
+// ConfigError is returned when a logger can't be built from its config,
+// e.g. an output path that can't be opened or an unknown encoding.
+type ConfigError struct {
+	// Logger is the logger that failed - app, gin, gorm or lib
+	Logger string
+	// LoggerName is the name from the logger config
+	LoggerName string
+	Err        error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("zlogger: unable to build the %s logger %q: %v", e.Logger, e.LoggerName, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,223 @@
+package zlogger
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+/* DOCS -
+rotating file output registered with zap as the "rotate" sink scheme
+rotate:///var/log/service.log?maxSize=100&maxAge=7&maxBackups=3&compress=true
+
+loggers writing to the same file share one sink,
+so the app, gin and gorm loggers rotate the file together
+a file keeps the rotation settings it was first opened with,
+opening it again with other settings is an error
+*/
+
+const FILE_SINK_SCHEME string = "rotate"
+
+type FileSinkConfig struct {
+	// Filename is the file to write to, backups are kept in the same directory
+	Filename string
+	// MaxSizeMB is the size in megabytes after which the file is rotated (lumberjack default 100)
+	MaxSizeMB int
+	// MaxAgeDays is the number of days to keep rotated files, 0 keeps them forever
+	MaxAgeDays int
+	// MaxBackups is the number of rotated files to keep, 0 keeps all of them
+	MaxBackups int
+	// Compress gzips the rotated files
+	Compress bool
+	// LocalTime uses local time instead of UTC in the backup file names
+	LocalTime bool
+}
+
+// URL returns the zap output path for this file sink.
+func (fc FileSinkConfig) URL() string {
+	query := url.Values{}
+	if fc.MaxSizeMB > 0 {
+		query.Set("maxSize", strconv.Itoa(fc.MaxSizeMB))
+	}
+	if fc.MaxAgeDays > 0 {
+		query.Set("maxAge", strconv.Itoa(fc.MaxAgeDays))
+	}
+	if fc.MaxBackups > 0 {
+		query.Set("maxBackups", strconv.Itoa(fc.MaxBackups))
+	}
+	if fc.Compress {
+		query.Set("compress", "true")
+	}
+	if fc.LocalTime {
+		query.Set("localTime", "true")
+	}
+	sinkURL := url.URL{
+		Scheme:   FILE_SINK_SCHEME,
+		Path:     filepath.ToSlash(fc.Filename),
+		RawQuery: query.Encode(),
+	}
+	if !filepath.IsAbs(fc.Filename) {
+		// relative paths are kept opaque - rotate:logs/service.log
+		sinkURL.Opaque = sinkURL.Path
+		sinkURL.Path = ""
+	}
+	return sinkURL.String()
+}
+
+func parseFileSinkURL(sinkURL *url.URL) (FileSinkConfig, error) {
+	fileSinkConfig := FileSinkConfig{Filename: sinkURL.Path}
+	if sinkURL.Opaque != "" {
+		fileSinkConfig.Filename = sinkURL.Opaque
+	}
+	if fileSinkConfig.Filename == "" {
+		return fileSinkConfig, fmt.Errorf("file sink %q has no file name", sinkURL.String())
+	}
+	fileSinkConfig.Filename = filepath.FromSlash(fileSinkConfig.Filename)
+
+	query := sinkURL.Query()
+	intParams := map[string]*int{
+		"maxSize":    &fileSinkConfig.MaxSizeMB,
+		"maxAge":     &fileSinkConfig.MaxAgeDays,
+		"maxBackups": &fileSinkConfig.MaxBackups,
+	}
+	for key, target := range intParams {
+		if value := query.Get(key); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed < 0 {
+				return fileSinkConfig, fmt.Errorf("file sink %q has an invalid %s %q", sinkURL.String(), key, value)
+			}
+			*target = parsed
+		}
+	}
+	boolParams := map[string]*bool{
+		"compress":  &fileSinkConfig.Compress,
+		"localTime": &fileSinkConfig.LocalTime,
+	}
+	for key, target := range boolParams {
+		if value := query.Get(key); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fileSinkConfig, fmt.Errorf("file sink %q has an invalid %s %q", sinkURL.String(), key, value)
+			}
+			*target = parsed
+		}
+	}
+	return fileSinkConfig, nil
+}
+
+type fileSink struct {
+	*lumberjack.Logger
+	// the settings the file was opened with, Filename is absolute
+	config FileSinkConfig
+}
+
+// lumberjack writes straight to the file, there is nothing to flush
+func (fileSink) Sync() error {
+	return nil
+}
+
+var (
+	_fileSinksMu   sync.Mutex
+	_fileSinks     = map[string]fileSink{}
+	_sighupWatcher sync.Once
+)
+
+func init() {
+	if err := zap.RegisterSink(FILE_SINK_SCHEME, newFileSink); err != nil {
+		panic(err)
+	}
+}
+
+func newFileSink(sinkURL *url.URL) (zap.Sink, error) {
+	fileSinkConfig, err := parseFileSinkURL(sinkURL)
+	if err != nil {
+		return nil, err
+	}
+	filename, err := filepath.Abs(fileSinkConfig.Filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return nil, err
+	}
+
+	fileSinkConfig.Filename = filename
+
+	_fileSinksMu.Lock()
+	defer _fileSinksMu.Unlock()
+	if sink, ok := _fileSinks[filename]; ok {
+		// lumberjack reads its settings while writing, they can't be changed
+		if sink.config != fileSinkConfig {
+			return nil, fmt.Errorf("file sink %q is already open with other rotation settings", filename)
+		}
+		return sink, nil
+	}
+	sink := fileSink{Logger: &lumberjack.Logger{
+		Filename:   filename,
+		MaxSize:    fileSinkConfig.MaxSizeMB,
+		MaxAge:     fileSinkConfig.MaxAgeDays,
+		MaxBackups: fileSinkConfig.MaxBackups,
+		Compress:   fileSinkConfig.Compress,
+		LocalTime:  fileSinkConfig.LocalTime,
+	}, config: fileSinkConfig}
+	_fileSinks[filename] = sink
+	_sighupWatcher.Do(watchSighup)
+	return sink, nil
+}
+
+// reopen the files on SIGHUP, after an external tool like logrotate moved them
+func watchSighup() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for range signals {
+			ReopenFileSinks()
+		}
+	}()
+}
+
+// ReopenFileSinks closes every rotating file, the next write reopens it.
+func ReopenFileSinks() error {
+	_fileSinksMu.Lock()
+	defer _fileSinksMu.Unlock()
+	var err error
+	for _, sink := range _fileSinks {
+		// lumberjack reopens the file on the next write
+		if closeErr := sink.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func closeFileSinks() error {
+	_fileSinksMu.Lock()
+	defer _fileSinksMu.Unlock()
+	var err error
+	for _, sink := range _fileSinks {
+		err = multierr.Append(err, sink.Close())
+	}
+	return err
+}
+
+// RotateFileSinks rotates every file sink right away.
+func RotateFileSinks() error {
+	_fileSinksMu.Lock()
+	defer _fileSinksMu.Unlock()
+	var err error
+	for _, sink := range _fileSinks {
+		if rotateErr := sink.Rotate(); rotateErr != nil && err == nil {
+			err = rotateErr
+		}
+	}
+	return err
+}
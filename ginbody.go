@@ -0,0 +1,267 @@
+package zlogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+/* DOCS -
+opt-in request / response body capture of the native gin middleware
+bodies are captured up to MaxBytes, only for the configured content types and routes
+sensitive json / form fields are redacted before they are logged
+*/
+
+// replaces the value of redacted fields
+const REDACTED string = "[REDACTED]"
+
+// field names redacted by default, compared without case, "_" and "-"
+var defaultRedactFields = []string{
+	"password", "passwd", "secret", "token", "accessToken", "refreshToken", "idToken",
+	"authorization", "apiKey", "cardNumber", "pan", "cvv", "cvc",
+}
+
+type BodyCapture struct {
+	// MaxBytes captured of each body, the rest is logged as truncated (default 4096)
+	MaxBytes int
+	// ContentTypes captured, "text/*" matches every subtype (default application/json)
+	ContentTypes []string
+	// Routes templates captured (/users/:id), every route when empty
+	Routes []string
+	// RedactFields are redacted on top of the default ones (password, token, cardNumber ...)
+	RedactFields []string
+	// RedactPaths are dotted json paths (user.card.number), array elements are walked through
+	RedactPaths []string
+}
+
+type bodyCapturer struct {
+	maxBytes     int
+	contentTypes []string
+	routes       map[string]struct{}
+	redactFields map[string]struct{}
+	redactPaths  map[string]struct{}
+}
+
+func newBodyCapturer(bodyCapture BodyCapture) *bodyCapturer {
+	bc := &bodyCapturer{
+		maxBytes:     bodyCapture.MaxBytes,
+		contentTypes: bodyCapture.ContentTypes,
+		redactFields: map[string]struct{}{},
+		redactPaths:  map[string]struct{}{},
+	}
+	if bc.maxBytes <= 0 {
+		bc.maxBytes = 4096
+	}
+	if len(bc.contentTypes) == 0 {
+		bc.contentTypes = []string{"application/json"}
+	}
+	if len(bodyCapture.Routes) > 0 {
+		bc.routes = make(map[string]struct{}, len(bodyCapture.Routes))
+		for _, route := range bodyCapture.Routes {
+			bc.routes[route] = struct{}{}
+		}
+	}
+	for _, field := range append(defaultRedactFields, bodyCapture.RedactFields...) {
+		bc.redactFields[normalizeFieldName(field)] = struct{}{}
+	}
+	for _, redactPath := range bodyCapture.RedactPaths {
+		bc.redactPaths[redactPath] = struct{}{}
+	}
+	return bc
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+func (bc *bodyCapturer) capturesRoute(route string) bool {
+	if bc.routes == nil {
+		return true
+	}
+	_, ok := bc.routes[route]
+	return ok
+}
+
+func (bc *bodyCapturer) capturesContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, captured := range bc.contentTypes {
+		if captured == mediaType {
+			return true
+		}
+		if strings.HasSuffix(captured, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(captured, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+type capturedBody struct {
+	body        bytes.Buffer
+	contentType string
+	truncated   bool
+}
+
+// reads up to maxBytes of the request body, the handlers still get all of it
+func (bc *bodyCapturer) captureRequest(c *gin.Context) *capturedBody {
+	contentType := c.GetHeader("Content-Type")
+	if c.Request.Body == nil || c.Request.Body == http.NoBody || !bc.capturesContentType(contentType) {
+		return nil
+	}
+	captured := &capturedBody{contentType: contentType}
+	original := c.Request.Body
+	// one more byte tells if the body is truncated
+	read, _ := io.CopyN(&captured.body, original, int64(bc.maxBytes)+1)
+	if read > int64(bc.maxBytes) {
+		captured.truncated = true
+	}
+	c.Request.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(captured.body.Bytes()), original),
+		Closer: original,
+	}
+	if captured.truncated {
+		captured.body.Truncate(bc.maxBytes)
+	}
+	return captured
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// copies up to maxBytes of the response body
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	captured *capturedBody
+	maxBytes int
+}
+
+func (w *bodyCaptureWriter) capture(b []byte) {
+	remaining := w.maxBytes - w.captured.body.Len()
+	if len(b) > remaining {
+		b = b[:remaining]
+		w.captured.truncated = true
+	}
+	w.captured.body.Write(b)
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCaptureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (bc *bodyCapturer) captureResponse(c *gin.Context) *capturedBody {
+	captured := &capturedBody{}
+	c.Writer = &bodyCaptureWriter{ResponseWriter: c.Writer, captured: captured, maxBytes: bc.maxBytes}
+	return captured
+}
+
+func (bc *bodyCapturer) requestFields(captured *capturedBody) []zap.Field {
+	if captured == nil {
+		return nil
+	}
+	return bc.fields("requestBody", captured)
+}
+
+func (bc *bodyCapturer) responseFields(c *gin.Context, captured *capturedBody) []zap.Field {
+	captured.contentType = c.Writer.Header().Get("Content-Type")
+	if captured.body.Len() == 0 || !bc.capturesContentType(captured.contentType) {
+		return nil
+	}
+	return bc.fields("responseBody", captured)
+}
+
+func (bc *bodyCapturer) fields(key string, captured *capturedBody) []zap.Field {
+	var fields []zap.Field
+	mediaType, _, _ := mime.ParseMediaType(captured.contentType)
+	body := captured.body.Bytes()
+
+	switch {
+	case !captured.truncated && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")):
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if decoder.Decode(&value) == nil {
+			if redacted, err := json.Marshal(bc.redactJSON(value, "")); err == nil {
+				// logged as json, not as a string
+				fields = append(fields, zap.Reflect(key, json.RawMessage(redacted)))
+				break
+			}
+		}
+		fields = append(fields, zap.String(key, bc.redactText(string(body))))
+	case mediaType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(body)); err == nil && !captured.truncated {
+			for name := range values {
+				if bc.redactsField(name) {
+					values[name] = []string{REDACTED}
+				}
+			}
+			fields = append(fields, zap.String(key, values.Encode()))
+			break
+		}
+		fields = append(fields, zap.String(key, bc.redactText(string(body))))
+	default:
+		fields = append(fields, zap.String(key, bc.redactText(string(body))))
+	}
+
+	if captured.truncated {
+		fields = append(fields, zap.Bool(key+"Truncated", true))
+	}
+	return fields
+}
+
+func (bc *bodyCapturer) redactsField(name string) bool {
+	_, ok := bc.redactFields[normalizeFieldName(name)]
+	return ok
+}
+
+func (bc *bodyCapturer) redactJSON(value interface{}, path string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, child := range v {
+			childPath := name
+			if path != "" {
+				childPath = path + "." + name
+			}
+			if _, ok := bc.redactPaths[childPath]; ok || bc.redactsField(name) {
+				v[name] = REDACTED
+				continue
+			}
+			v[name] = bc.redactJSON(child, childPath)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = bc.redactJSON(child, path)
+		}
+	}
+	return value
+}
+
+// "name": value pairs of json that could not be parsed, a truncated body ...
+var jsonFieldPattern = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"(\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`)
+
+func (bc *bodyCapturer) redactText(body string) string {
+	return jsonFieldPattern.ReplaceAllStringFunc(body, func(pair string) string {
+		match := jsonFieldPattern.FindStringSubmatch(pair)
+		if !bc.redactsField(match[1]) {
+			return pair
+		}
+		return `"` + match[1] + `"` + match[2] + `"` + REDACTED + `"`
+	})
+}
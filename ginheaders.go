@@ -0,0 +1,125 @@
+package zlogger
+
+import (
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+/* DOCS -
+opt-in request / response header logging of the native gin middleware
+header names are matched without case, the values of secret headers are masked
+*/
+
+// headers masked by default
+var defaultMaskedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+type HeaderLogging struct {
+	// Request logs the request headers as "requestHeaders"
+	Request bool
+	// Response logs the response headers as "responseHeaders"
+	Response bool
+	// Allow lists the logged headers, every header when empty
+	Allow []string
+	// Deny lists headers never logged, it wins over Allow
+	Deny []string
+	// Mask lists headers whose values are masked, on top of Authorization, Proxy-Authorization, Cookie and Set-Cookie
+	Mask []string
+}
+
+type headerLogger struct {
+	request  bool
+	response bool
+	allow    map[string]struct{}
+	deny     map[string]struct{}
+	mask     map[string]struct{}
+}
+
+func canonicalHeaderSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[http.CanonicalHeaderKey(strings.TrimSpace(name))] = struct{}{}
+	}
+	return set
+}
+
+func newHeaderLogger(headerLogging HeaderLogging) *headerLogger {
+	hl := &headerLogger{
+		request:  headerLogging.Request,
+		response: headerLogging.Response,
+		deny:     canonicalHeaderSet(headerLogging.Deny),
+		mask:     canonicalHeaderSet(append(defaultMaskedHeaders, headerLogging.Mask...)),
+	}
+	if len(headerLogging.Allow) > 0 {
+		hl.allow = canonicalHeaderSet(headerLogging.Allow)
+	}
+	return hl
+}
+
+func (hl *headerLogger) logs(name string) bool {
+	if _, ok := hl.deny[name]; ok {
+		return false
+	}
+	if hl.allow == nil {
+		return true
+	}
+	_, ok := hl.allow[name]
+	return ok
+}
+
+// header values joined by ", ", keyed by canonical name
+func (hl *headerLogger) headers(header http.Header) map[string]string {
+	logged := map[string]string{}
+	for name, values := range header {
+		name = http.CanonicalHeaderKey(name)
+		if !hl.logs(name) {
+			continue
+		}
+		if _, ok := hl.mask[name]; ok {
+			masked := make([]string, len(values))
+			for i, value := range values {
+				masked[i] = maskHeaderValue(name, value)
+			}
+			values = masked
+		}
+		logged[name] = strings.Join(values, ", ")
+	}
+	return logged
+}
+
+func (hl *headerLogger) fields(request, response http.Header) []zap.Field {
+	var fields []zap.Field
+	if hl.request {
+		fields = append(fields, zap.Any("requestHeaders", hl.headers(request)))
+	}
+	if hl.response {
+		fields = append(fields, zap.Any("responseHeaders", hl.headers(response)))
+	}
+	return fields
+}
+
+// keeps what helps debugging - the auth scheme, the cookie names and attributes
+func maskHeaderValue(name, value string) string {
+	switch name {
+	case "Authorization", "Proxy-Authorization":
+		if scheme, _, ok := strings.Cut(value, " "); ok {
+			return scheme + " " + REDACTED
+		}
+	case "Cookie":
+		cookies := strings.Split(value, ";")
+		for i, cookie := range cookies {
+			cookieName, _, _ := strings.Cut(strings.TrimSpace(cookie), "=")
+			cookies[i] = cookieName + "=" + REDACTED
+		}
+		return strings.Join(cookies, "; ")
+	case "Set-Cookie":
+		cookie, attributes, _ := strings.Cut(value, ";")
+		cookieName, _, _ := strings.Cut(strings.TrimSpace(cookie), "=")
+		if attributes != "" {
+			return cookieName + "=" + REDACTED + ";" + attributes
+		}
+		return cookieName + "=" + REDACTED
+	}
+	return REDACTED
+}
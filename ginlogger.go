@@ -1,84 +1,202 @@
 package zlogger
 
 import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-var gl ginLogger
-
 type ginLogger struct {
 	*zap.Logger
+	loggerType LoggerType
+	skipRoutes *ginSkipRoutes
+	// writes the plain lines of the common, combined and w3c formats
+	accessLogger *zap.Logger
+	level        zap.AtomicLevel
+}
+
+// skipped routes, replaced on reload while requests are logged
+type ginSkipRoutes struct {
+	matcher atomic.Pointer[skipRouteMatcher]
+}
+
+func newGinSkipRoutes(skipRoutes []string) (*ginSkipRoutes, error) {
+	sr := &ginSkipRoutes{}
+	if err := sr.set(skipRoutes); err != nil {
+		return nil, err
+	}
+	return sr, nil
+}
+
+func (sr *ginSkipRoutes) set(skipRoutes []string) error {
+	matcher, err := compileSkipRoutes(skipRoutes)
+	if err != nil {
+		return err
+	}
+	sr.matcher.Store(matcher)
+	return nil
 }
 
-func NewGinLoggerConfig(loggerConfig loggerConfig, skipRoutes []string) gin.LoggerConfig {
-	if skipRoutes != nil {
+func (sr *ginSkipRoutes) skip(method, path string) bool {
+	// params.Path carries the raw query
+	path, _, _ = strings.Cut(path, "?")
+	return sr.matcher.Load().match(method, path)
+}
+
+func NewGinLoggerConfig(loggerConfig LoggerConfig, skipRoutes []string) (gin.LoggerConfig, error) {
+	gl, err := newGinLogger(loggerConfig, skipRoutes)
+	if err != nil {
+		return gin.LoggerConfig{}, err
+	}
+	// routes are skipped by the formatter, so they can be changed on reload
+	return gl.loggerConfig(), nil
+}
+
+func (gl *ginLogger) loggerConfig() gin.LoggerConfig {
+	return gin.LoggerConfig{
+		Formatter: gin.LogFormatter(gl.ginRequestLoggerMiddleware),
+	}
+}
+
+func newGinLogger(loggerConfig LoggerConfig, skipRoutes []string) (*ginLogger, error) {
+	if skipRoutes == nil {
 		skipRoutes = []string{}
 	}
-	_libLogger := generateZapLogger(&loggerConfig.config, "lib")
+	ginSkipRoutes, err := newGinSkipRoutes(skipRoutes)
+	if err != nil {
+		return nil, &ConfigError{Logger: GIN_LOGGER, LoggerName: loggerConfig.loggerName, Err: err}
+	}
+	_libLogger, err := generateZapLogger(&loggerConfig, "lib")
+	if err != nil {
+		return nil, &ConfigError{Logger: "lib", LoggerName: loggerConfig.loggerName, Err: err}
+	}
 	loggerConfig.config.DisableCaller = true
+	// the stack of the middleware is noise, recovery adds the stack of the panic
+	loggerConfig.config.DisableStacktrace = true
 
 	loggerConfig.config.EncoderConfig.MessageKey = "requestUrl"
-	gl = ginLogger{generateZapLogger(&loggerConfig.config, loggerConfig.loggerName)}
-	gin.DebugPrintRouteFunc = ginDebugLogger
+	// own level, so it can be changed independently of the app logger
+	loggerConfig.config.Level = zap.NewAtomicLevelAt(loggerConfig.config.Level.Level())
+	_zapLogger, err := generateZapLogger(&loggerConfig, loggerConfig.loggerName)
+	if err != nil {
+		return nil, &ConfigError{Logger: GIN_LOGGER, LoggerName: loggerConfig.loggerName, Err: err}
+	}
+	accessLogConfig := plainLineConfig(loggerConfig)
+	// named like the json entries, so the <name>.gin level override applies
+	_accessLogger, err := generateZapLogger(&accessLogConfig, CreateLoggerName(loggerConfig.loggerName, "gin"))
+	if err != nil {
+		return nil, &ConfigError{Logger: GIN_LOGGER, LoggerName: loggerConfig.loggerName, Err: err}
+	}
+	gl := &ginLogger{_zapLogger, loggerConfig.loggerType, ginSkipRoutes, _accessLogger, loggerConfig.config.Level}
+	trackGinSkipRoutes(loggerConfig.setID, gl.skipRoutes)
 
 	if loggerConfig.loggerType == DEBUG_LOGGER {
 		_libLogger.Info("created a [DEBUG-GIN-LOGGER] with logger-name :: " + loggerConfig.loggerName)
 	} else if loggerConfig.loggerType == JSON_LOGGER {
 		_libLogger.Info("created a [JSON-GIN-LOGGER] with logger-name :: " + loggerConfig.loggerName)
 	}
-	// set logger function to
-	// print routes for this logger
-	//gin.DebugPrintRouteFunc = _ginLogger.ginDebugLogger
-	return gin.LoggerConfig{
-		SkipPaths: skipRoutes,
-		Formatter: gin.LogFormatter(ginRequestLoggerMiddleware),
+	return gl, nil
+}
+
+// MustNewGinLoggerConfig is like NewGinLoggerConfig but panics if the logger can't be built
+func MustNewGinLoggerConfig(loggerConfig LoggerConfig, skipRoutes []string) gin.LoggerConfig {
+	ginConfig, err := NewGinLoggerConfig(loggerConfig, skipRoutes)
+	if err != nil {
+		panic(err)
+	}
+	return ginConfig
+}
+
+// logs through zap and returns "", gin still writes an empty line to its output,
+// use the GinLogger middleware instead
+func (gl *ginLogger) ginRequestLoggerMiddleware(params gin.LogFormatterParams) string {
+	if gl.skipRoutes.skip(params.Method, params.Path) {
+		return ""
+	}
+	gl.logRequest(params, params.Path, DefaultStatusLevels().Level(params.StatusCode))
+	return ""
+}
+
+// default format of the logger type
+func (gl *ginLogger) defaultFormat() AccessLogFormat {
+	if gl.loggerType == JSON_LOGGER {
+		return JSON_FORMAT
 	}
+	return CONSOLE_FORMAT
+}
+
+// fields are added after the default ones
+func (gl *ginLogger) logRequest(params gin.LogFormatterParams, message string, level zapcore.Level, fields ...zap.Field) {
+	gl.logFormattedRequest(gl.defaultFormat(), params, message, level, latencyBands{}, false, fields...)
 }
 
-func ginRequestLoggerMiddleware(params gin.LogFormatterParams) string {
-	if gl.Level() > zapcore.DebugLevel {
+func (gl *ginLogger) logFormattedRequest(format AccessLogFormat, params gin.LogFormatterParams, message string, level zapcore.Level, bands latencyBands, slow bool, fields ...zap.Field) {
+	if format == JSON_FORMAT {
 		// PRODUCTION
 
-		gl.Named("gin").Info(params.Path,
+		requestFields := []zap.Field{
 			zap.Int("statusCode", params.StatusCode),
 			zap.String("requestMethod", params.Method),
 			zap.String("error", params.ErrorMessage),
 			zap.String("clientIP", params.ClientIP),
 			zap.Duration("latency", params.Latency),
-		)
+		}
+		if requestID, ok := params.Keys[REQUEST_ID_KEY].(string); ok {
+			requestFields = append(requestFields, zap.String("requestId", requestID))
+		}
+		requestFields = append(requestFields, ginSpanFields(params)...)
+		gl.Named("gin").Log(level, message, append(requestFields, fields...)...)
 	} else {
 			// DEBUG
 			var formatedStatusCode string = colorifySatusCode(params.StatusCode)
 			var formatedRequestMethod string = colorifyRequestMethod(params.Method)
-			var formatedLatency string = colorifyRequestLatency(params.Latency)
+			var formatedLatency string = bands.colorify(params.Latency, slow)
+			if slow {
+				formatedLatency += " " + colorPallet.colorbgRed("SLOW")
+			}
+			var formatedRequestID string
+			if requestID, ok := params.Keys[REQUEST_ID_KEY].(string); ok {
+				formatedRequestID = "\t" + requestID
+			}
+			var debugLogger *zap.Logger = gl.Named("gin").With(append(ginSpanFields(params), fields...)...)
 
 			if(params.ErrorMessage != "") {
 				var formattedError string = colorifyRequestError(params.ErrorMessage)
-				gl.Named("gin").Sugar().Errorf("%-18s%-20s%s\t%s\t%s\t%s",
+				debugLogger.Log(level, fmt.Sprintf("%-18s%-20s%s\t%s\t%s\t%s%s",
 					formatedStatusCode,
 					formatedRequestMethod,
-					params.Path,
+					message,
 					formattedError,
 					params.ClientIP,
-					formatedLatency)
+					formatedLatency,
+					formatedRequestID))
 			} else {
-				gl.Named("gin").Sugar().Infof("%-18s%-20s%s\t%s\t%s",
+				debugLogger.Log(level, fmt.Sprintf("%-18s%-20s%s\t%s\t%s%s",
 					formatedStatusCode,
 					formatedRequestMethod,
-					params.Path,
+					message,
 					params.ClientIP,
-					formatedLatency)
+					formatedLatency,
+					formatedRequestID))
 			}
 			
 	}
-	return ""
+}
+
+// trace fields of the span stored by the TraceContext middleware
+func ginSpanFields(params gin.LogFormatterParams) []zap.Field {
+	spanContext, _ := params.Keys[SPAN_CONTEXT_KEY].(trace.SpanContext)
+	return spanContextFields(spanContext)
 }
 
 // for printing all the routes defined in gin
-func ginDebugLogger(httpMethod, absolutePath, handlerName string, nuHandlers int) {
-	if gl.Level() > zapcore.DebugLevel {
+func (gl *ginLogger) ginDebugLogger(httpMethod, absolutePath, handlerName string, nuHandlers int) {
+	if gl.loggerType == JSON_LOGGER {
 		// PRODUCTION
 		gl.Named("gin").Info(absolutePath, 
 		zap.String("requestMethod", httpMethod),
@@ -0,0 +1,161 @@
+package zlogger
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+native gin middleware, logs every request itself through zap
+no gin.LoggerWithConfig, so nothing is written to gin's output
+adds the route template, the request / response sizes, every handler error of c.Errors
+and, with WithHeaderLogging / WithBodyCapture, the request / response headers and bodies
+*/
+
+// NewGinLogger returns a gin middleware logging every request, except skipRoutes
+func NewGinLogger(loggerConfig LoggerConfig, skipRoutes []string, options ...GinOption) (gin.HandlerFunc, error) {
+	gl, err := newGinLogger(loggerConfig, skipRoutes)
+	if err != nil {
+		return nil, err
+	}
+	return gl.middleware(options...), nil
+}
+
+// MustNewGinLogger is like NewGinLogger but panics if the logger can't be built
+func MustNewGinLogger(loggerConfig LoggerConfig, skipRoutes []string, options ...GinOption) gin.HandlerFunc {
+	ginLogger, err := NewGinLogger(loggerConfig, skipRoutes, options...)
+	if err != nil {
+		panic(err)
+	}
+	return ginLogger
+}
+
+func (gl *ginLogger) middleware(options ...GinOption) gin.HandlerFunc {
+	opts := newGinOptions(options)
+	var sampler *requestSampler
+	if opts.sampling != nil && opts.sampling.Every > 0 {
+		sampler = newRequestSampler(*opts.sampling, gl.Named("gin"))
+	}
+	var bodyCapturer *bodyCapturer
+	if opts.bodyCapture != nil {
+		bodyCapturer = newBodyCapturer(*opts.bodyCapture)
+	}
+	var headerLogger *headerLogger
+	if opts.headerLogging != nil {
+		headerLogger = newHeaderLogger(*opts.headerLogging)
+	}
+	if opts.format == "" {
+		opts.format = gl.defaultFormat()
+	}
+	plainLine := plainLineFormatter(opts.format, opts.w3cFields)
+	if opts.format == W3C_FORMAT {
+		gl.writeW3CDirectives(opts.w3cFields)
+	}
+	return func(c *gin.Context) {
+		if gl.skipRoutes.skip(c.Request.Method, c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+		start := time.Now()
+		path := c.Request.URL.Path
+		rawQuery := c.Request.URL.RawQuery
+
+		// gin matched the route before running the handlers
+		var requestBody, responseBody *capturedBody
+		if bodyCapturer != nil && bodyCapturer.capturesRoute(opts.route(c)) {
+			requestBody = bodyCapturer.captureRequest(c)
+			responseBody = bodyCapturer.captureResponse(c)
+		}
+
+		c.Next()
+
+		if opts.skip(c) {
+			return
+		}
+		now := time.Now()
+		route := opts.route(c)
+		slow := opts.slow(route, now.Sub(start))
+		if sampler != nil {
+			if !sampler.keep(route, c.Writer.Status(), len(c.Errors) > 0 || slow) {
+				return
+			}
+		}
+
+		params := gin.LogFormatterParams{
+			Request:      c.Request,
+			TimeStamp:    now,
+			StatusCode:   c.Writer.Status(),
+			ClientIP:     c.ClientIP(),
+			Method:       c.Request.Method,
+			Path:         path,
+			ErrorMessage: c.Errors.ByType(gin.ErrorTypePrivate).String(),
+			BodySize:     c.Writer.Size(),
+			Keys:         c.Keys,
+		}
+		params.Latency = params.TimeStamp.Sub(start)
+		if rawQuery != "" {
+			params.Path = path + "?" + rawQuery
+		}
+
+		level := opts.level(route, params.StatusCode, slow)
+		if plainLine != nil {
+			gl.accessLogger.Log(level, plainLine(params))
+			return
+		}
+
+		fields := []zap.Field{zap.String("route", route)}
+		if slow {
+			fields = append(fields, zap.Bool("slow", true))
+		}
+		message := params.Path
+		if opts.routeAsMessage {
+			message = route
+			fields = append(fields, zap.String("path", params.Path))
+		}
+		fields = append(fields, requestStatsFields(c, params)...)
+		if headerLogger != nil {
+			fields = append(fields, headerLogger.fields(c.Request.Header, c.Writer.Header())...)
+		}
+		if responseBody != nil {
+			fields = append(fields, bodyCapturer.requestFields(requestBody)...)
+			fields = append(fields, bodyCapturer.responseFields(c, responseBody)...)
+		}
+		gl.logFormattedRequest(opts.format, params, message, level, opts.latencyBands, slow, fields...)
+	}
+}
+
+// request / response sizes and handler errors
+func requestStatsFields(c *gin.Context, params gin.LogFormatterParams) []zap.Field {
+	// -1 when unknown or nothing was written
+	var requestSize int64 = c.Request.ContentLength
+	if requestSize < 0 {
+		requestSize = 0
+	}
+	var responseSize int = params.BodySize
+	if responseSize < 0 {
+		responseSize = 0
+	}
+	fields := []zap.Field{
+		zap.Int64("requestSize", requestSize),
+		zap.Int("responseSize", responseSize),
+	}
+	if len(c.Errors) > 0 {
+		fields = append(fields, zap.Strings("errors", c.Errors.Errors()))
+	}
+	return fields
+}
+
+// the directives describe the lines, they are written whatever the gin level is
+func (gl *ginLogger) writeW3CDirectives(w3cFields []string) {
+	entry := zapcore.Entry{
+		Time:    time.Now(),
+		Level:   zapcore.InfoLevel,
+		Message: strings.TrimSuffix(W3CLogDirectives(w3cFields...), "\n"),
+	}
+	// Core().Write skips the level and override checks of the logger
+	gl.accessLogger.Core().Write(entry, nil)
+}
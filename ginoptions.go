@@ -0,0 +1,202 @@
+package zlogger
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+functional options for the native gin middleware (NewGinLogger / GetGinLogger)
+*/
+
+// route label of requests not matching any route (404)
+const UNMATCHED_ROUTE string = "unmatched"
+
+type GinOption func(*ginOptions)
+
+type ginOptions struct {
+	routeAsMessage      bool
+	unmatchedRoute      string
+	statusLevels        StatusLevels
+	routeStatusLevels   map[string]StatusLevels
+	sampling            *RequestSampling
+	skippers            []func(c *gin.Context) bool
+	bodyCapture         *BodyCapture
+	headerLogging       *HeaderLogging
+	format              AccessLogFormat
+	w3cFields           []string
+	slowThreshold       time.Duration
+	routeSlowThresholds map[string]time.Duration
+	latencyBands        latencyBands
+}
+
+// latency colors of the console format, the fixed bands of colorifyRequestLatency when zero
+type latencyBands struct {
+	yellowFrom time.Duration
+	redFrom    time.Duration
+}
+
+func (lb latencyBands) colorify(latency time.Duration, slow bool) string {
+	if slow {
+		return colorPallet.colorfgRed(latency.String())
+	}
+	if lb == (latencyBands{}) {
+		return colorifyRequestLatency(latency)
+	}
+	return colorifyLatencyBands(latency, lb.yellowFrom, lb.redFrom)
+}
+
+func newGinOptions(options []GinOption) ginOptions {
+	opts := ginOptions{
+		unmatchedRoute:      UNMATCHED_ROUTE,
+		statusLevels:        DefaultStatusLevels(),
+		routeStatusLevels:   map[string]StatusLevels{},
+		routeSlowThresholds: map[string]time.Duration{},
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// WithRouteAsMessage logs the route template (/users/:id) as the message,
+// the raw path moves to the "path" field
+func WithRouteAsMessage(routeAsMessage bool) GinOption {
+	return func(opts *ginOptions) {
+		opts.routeAsMessage = routeAsMessage
+	}
+}
+
+// WithUnmatchedRoute sets the route label of requests not matching any route (default "unmatched")
+func WithUnmatchedRoute(label string) GinOption {
+	return func(opts *ginOptions) {
+		opts.unmatchedRoute = label
+	}
+}
+
+// route template of a request, c.FullPath() is "" when no route matched
+func (opts ginOptions) route(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return opts.unmatchedRoute
+}
+
+// level of a request, the levels of its route win over the default ones,
+// slow requests are logged at Warn at least
+func (opts ginOptions) level(route string, statusCode int, slow bool) zapcore.Level {
+	level := opts.statusLevels.Level(statusCode)
+	if statusLevels, ok := opts.routeStatusLevels[route]; ok {
+		level = statusLevels.Level(statusCode)
+	}
+	if slow && level < zapcore.WarnLevel {
+		return zapcore.WarnLevel
+	}
+	return level
+}
+
+// slow reports whether the request took at least the slow threshold of its route
+func (opts ginOptions) slow(route string, latency time.Duration) bool {
+	threshold := opts.slowThreshold
+	if routeThreshold, ok := opts.routeSlowThresholds[route]; ok {
+		threshold = routeThreshold
+	}
+	return threshold > 0 && latency >= threshold
+}
+
+// WithStatusLevels sets the levels of every route (default DefaultStatusLevels)
+func WithStatusLevels(statusLevels StatusLevels) GinOption {
+	return func(opts *ginOptions) {
+		opts.statusLevels = statusLevels
+	}
+}
+
+// WithRouteStatusLevels sets the levels of one route template (/users/:id),
+// e.g. Debug for the successful requests of a health check, every field
+// of statusLevels is used, see DefaultStatusLevels
+func WithRouteStatusLevels(route string, statusLevels StatusLevels) GinOption {
+	return func(opts *ginOptions) {
+		opts.routeStatusLevels[route] = statusLevels
+	}
+}
+
+// WithRequestSampling logs only sampling.First of every sampling.Every
+// successful requests of a route, errors and slow requests are always logged
+func WithRequestSampling(sampling RequestSampling) GinOption {
+	return func(opts *ginOptions) {
+		opts.sampling = &sampling
+	}
+}
+
+func (opts ginOptions) skip(c *gin.Context) bool {
+	for _, skipper := range opts.skippers {
+		if skipper(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithSkipper skips the requests skipper returns true for, on top of skipRoutes.
+// It runs after the handlers, so the response can be checked as well.
+func WithSkipper(skipper func(c *gin.Context) bool) GinOption {
+	return func(opts *ginOptions) {
+		if skipper != nil {
+			opts.skippers = append(opts.skippers, skipper)
+		}
+	}
+}
+
+// WithBodyCapture logs the request and response bodies, redacted and up to bodyCapture.MaxBytes
+func WithBodyCapture(bodyCapture BodyCapture) GinOption {
+	return func(opts *ginOptions) {
+		opts.bodyCapture = &bodyCapture
+	}
+}
+
+// WithHeaderLogging logs the request and / or response headers, secrets masked
+func WithHeaderLogging(headerLogging HeaderLogging) GinOption {
+	return func(opts *ginOptions) {
+		opts.headerLogging = &headerLogging
+	}
+}
+
+// WithAccessLogFormat sets the format of the entries, JSON_FORMAT / CONSOLE_FORMAT
+// by default. The plain formats leave out the headers, bodies and trace ids.
+func WithAccessLogFormat(format AccessLogFormat) GinOption {
+	return func(opts *ginOptions) {
+		opts.format = format
+	}
+}
+
+// WithW3CFields sets the fields of W3C_FORMAT, see W3CLogFormatter
+func WithW3CFields(fields ...string) GinOption {
+	return func(opts *ginOptions) {
+		opts.w3cFields = fields
+	}
+}
+
+// WithSlowThreshold logs requests at least threshold slow at Warn with a "slow" flag,
+// like GormLogger.SlowThreshold (0 disables)
+func WithSlowThreshold(threshold time.Duration) GinOption {
+	return func(opts *ginOptions) {
+		opts.slowThreshold = threshold
+	}
+}
+
+// WithRouteSlowThreshold sets the slow threshold of one route template (/users/:id)
+func WithRouteSlowThreshold(route string, threshold time.Duration) GinOption {
+	return func(opts *ginOptions) {
+		opts.routeSlowThresholds[route] = threshold
+	}
+}
+
+// WithLatencyColorBands sets the latency colors of the console format,
+// yellow from yellowFrom and red from redFrom (default 1s and 2s)
+func WithLatencyColorBands(yellowFrom time.Duration, redFrom time.Duration) GinOption {
+	return func(opts *ginOptions) {
+		opts.latencyBands = latencyBands{yellowFrom: yellowFrom, redFrom: redFrom}
+	}
+}
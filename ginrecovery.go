@@ -0,0 +1,92 @@
+package zlogger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+gin.Recovery replacement
+a recovered panic is logged by the gin logger at Error level with its value,
+stack trace, request id, route and method, then handle writes the response
+it shares the gin logger, and so the level, of the access log middleware of its logger set
+*/
+
+// handle writes the response (default an empty 500), options are read
+// for the route label of unmatched requests
+func (gl *ginLogger) recovery(handle gin.RecoveryFunc, options ...GinOption) gin.HandlerFunc {
+	opts := newGinOptions(options)
+	if handle == nil {
+		handle = func(c *gin.Context, _ interface{}) {
+			c.AbortWithStatus(http.StatusInternalServerError)
+		}
+	}
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// a dead connection is not worth a stack trace, nor a response
+			if isBrokenPipe(recovered) {
+				gl.logPanic(c, opts, recovered, "")
+				c.Error(recovered.(error))
+				c.Abort()
+				return
+			}
+			gl.logPanic(c, opts, recovered, string(debug.Stack()))
+			handle(c, recovered)
+		}()
+		c.Next()
+	}
+}
+
+func isBrokenPipe(recovered interface{}) bool {
+	opErr, ok := recovered.(*net.OpError)
+	if !ok {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if !errors.As(opErr, &syscallErr) {
+		return false
+	}
+	message := strings.ToLower(syscallErr.Error())
+	return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+}
+
+func (gl *ginLogger) logPanic(c *gin.Context, opts ginOptions, recovered interface{}, stack string) {
+	route := opts.route(c)
+	fields := []zap.Field{
+		zap.String("panic", fmt.Sprint(recovered)),
+		zap.String("route", route),
+		zap.String("requestMethod", c.Request.Method),
+		zap.String("clientIP", c.ClientIP()),
+	}
+	if requestID := RequestIDFromContext(c); requestID != "" {
+		fields = append(fields, zap.String("requestId", requestID))
+	}
+	fields = append(fields, traceFields(c.Request.Context())...)
+
+	message := c.Request.URL.Path
+	if gl.loggerType == DEBUG_LOGGER {
+		message = fmt.Sprintf("%-18s%-20s%s\t%s",
+			colorPallet.colorfgRed("PANIC"),
+			colorifyRequestMethod(c.Request.Method),
+			message,
+			colorifyRequestError(fmt.Sprint(recovered)))
+	}
+	if checked := gl.Named("gin").Check(zapcore.ErrorLevel, message); checked != nil {
+		// written under the stacktrace key, on its own lines by the console encoder
+		checked.Entry.Stack = stack
+		checked.Write(fields...)
+	}
+}
@@ -0,0 +1,128 @@
+package zlogger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+/* DOCS -
+sampling of successful gin requests, per route
+4xx / 5xx responses, requests with handler errors and slow requests
+(WithSlowThreshold / WithRouteSlowThreshold) are always logged
+the number of suppressed entries is logged every ReportInterval and on Shutdown
+*/
+
+type RequestSampling struct {
+	// First successful requests of every Every are logged, per route
+	First int
+	Every int
+	// ReportInterval is how often the suppressed entries are counted in a log entry (default 1m)
+	ReportInterval time.Duration
+}
+
+type routeSamplingCounters struct {
+	requests   atomic.Uint64
+	suppressed atomic.Uint64
+}
+
+type requestSampler struct {
+	sampling RequestSampling
+	routes   sync.Map // route -> *routeSamplingCounters
+	logger   *zap.Logger
+	// only read and written by the report goroutine
+	lastReport time.Time
+
+	stopOnce sync.Once
+	done     chan struct{}
+	stopped  chan struct{}
+}
+
+// the suppressed entries are reported by a goroutine, so quiet routes are
+// reported too, it is stopped by Shutdown after a last report
+func newRequestSampler(sampling RequestSampling, logger *zap.Logger) *requestSampler {
+	if sampling.ReportInterval <= 0 {
+		sampling.ReportInterval = time.Minute
+	}
+	rs := &requestSampler{
+		sampling:   sampling,
+		logger:     logger,
+		lastReport: time.Now(),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	go rs.run()
+	trackRequestSampler(rs)
+	return rs
+}
+
+func (rs *requestSampler) run() {
+	defer close(rs.stopped)
+	ticker := time.NewTicker(rs.sampling.ReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			rs.report(now)
+		case <-rs.done:
+			rs.report(time.Now())
+			return
+		}
+	}
+}
+
+// stop reports the entries suppressed since the last report and stops the goroutine
+func (rs *requestSampler) stop() {
+	rs.stopOnce.Do(func() {
+		close(rs.done)
+	})
+	<-rs.stopped
+}
+
+func (rs *requestSampler) counters(route string) *routeSamplingCounters {
+	if counters, ok := rs.routes.Load(route); ok {
+		return counters.(*routeSamplingCounters)
+	}
+	counters, _ := rs.routes.LoadOrStore(route, &routeSamplingCounters{})
+	return counters.(*routeSamplingCounters)
+}
+
+// keep reports whether the request is logged, always when mustLog (handler errors, slow route ...)
+func (rs *requestSampler) keep(route string, statusCode int, mustLog bool) bool {
+	if statusCode >= 400 || mustLog {
+		return true
+	}
+	counters := rs.counters(route)
+	n := counters.requests.Add(1) - 1
+	if n%uint64(rs.sampling.Every) < uint64(rs.sampling.First) {
+		return true
+	}
+	counters.suppressed.Add(1)
+	return false
+}
+
+// report logs the entries suppressed since the last report
+func (rs *requestSampler) report(now time.Time) {
+	last := rs.lastReport
+	rs.lastReport = now
+
+	var total uint64
+	suppressedByRoute := map[string]uint64{}
+	rs.routes.Range(func(route, counters interface{}) bool {
+		if suppressed := counters.(*routeSamplingCounters).suppressed.Swap(0); suppressed > 0 {
+			suppressedByRoute[route.(string)] = suppressed
+			total += suppressed
+		}
+		return true
+	})
+	if total == 0 {
+		return
+	}
+	rs.logger.Info("suppressed sampled requests",
+		zap.Uint64("suppressed", total),
+		zap.Any("suppressedByRoute", suppressedByRoute),
+		zap.Duration("interval", now.Sub(last)),
+	)
+}
@@ -20,21 +20,30 @@ type GormLogger struct {
 	IgnoreRecordNotFoundError bool
 }
 
-func setupGormLogger(db *gorm.DB, loggerConfig loggerConfig) GormLogger {
+// the level is returned for the logger set, gormlogger.Default and db.Logger
+// are set by the setup once every logger of the set is built
+func newGormLogger(loggerConfig LoggerConfig) (GormLogger, zap.AtomicLevel, error) {
 	loggerConfig.config.DisableCaller = true
 	loggerConfig.config.DisableStacktrace = true
 	
-	_libLogger := generateZapLogger(&loggerConfig.config, "lib")
-	_gormLogger := generateZapLogger(&loggerConfig.config, loggerConfig.loggerName)
-
+	_libLogger, err := generateZapLogger(&loggerConfig, "lib")
+	if err != nil {
+		return GormLogger{}, zap.AtomicLevel{}, &ConfigError{Logger: "lib", LoggerName: loggerConfig.loggerName, Err: err}
+	}
+	// own level, so it can be changed independently of the app logger
+	loggerConfig.config.Level = zap.NewAtomicLevelAt(loggerConfig.config.Level.Level())
+	_gormLogger, err := generateZapLogger(&loggerConfig, loggerConfig.loggerName)
+	if err != nil {
+		return GormLogger{}, zap.AtomicLevel{}, &ConfigError{Logger: GORM_LOGGER, LoggerName: loggerConfig.loggerName, Err: err}
+	}
 
 	gormLogger := GormLogger{
 		ZapLogger:                 _gormLogger,
 		LoggerMode:                gin.DebugMode,
-		LogLevel:                  gormlogger.Info,
-		SlowThreshold:             100 * time.Millisecond,
+		LogLevel:                  loggerConfig.gorm.logLevel,
+		SlowThreshold:             loggerConfig.gorm.slowThreshold,
 		SkipCallerLookup:          false,
-		IgnoreRecordNotFoundError: false,
+		IgnoreRecordNotFoundError: loggerConfig.gorm.ignoreRecordNotFoundError,
 	}
 
 	if loggerConfig.loggerType == DEBUG_LOGGER {
@@ -43,11 +52,7 @@ func setupGormLogger(db *gorm.DB, loggerConfig loggerConfig) GormLogger {
 		gormLogger.LoggerMode = gin.ReleaseMode
 		_libLogger.Info("created a [JSON-GORM-LOGGER] with logger-name :: " + loggerConfig.loggerName)
 	}
-	gormlogger.Default = gormLogger
-	if db != nil {
-		db.Logger = gormLogger
-	}
-	return gormLogger
+	return gormLogger, loggerConfig.config.Level, nil
 }
 
 // try to accomodate this in NewGormLogger func
@@ -65,25 +70,34 @@ func (l GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
 	}
 }
 
+// the fields carried by ctx (request id ...) are attached to every entry
+func (l GormLogger) zapLogger(ctx context.Context) *zap.Logger {
+	zapLogger := l.ZapLogger.Named("gorm")
+	if ctxFields := FieldsFromContext(ctx); len(ctxFields) > 0 {
+		zapLogger = zapLogger.With(ctxFields...)
+	}
+	return zapLogger
+}
+
 func (l GormLogger) Info(ctx context.Context, str string, args ...interface{}) {
 	if l.LogLevel < gormlogger.Info {
 		return
 	}
-	l.ZapLogger.Named("gorm").Sugar().Debugf(str, args...)
+	l.zapLogger(ctx).Sugar().Debugf(str, args...)
 }
 
 func (l GormLogger) Warn(ctx context.Context, str string, args ...interface{}) {
 	if l.LogLevel < gormlogger.Warn {
 		return
 	}
-	l.ZapLogger.Named("gorm").Sugar().Warnf(str, args...)
+	l.zapLogger(ctx).Sugar().Warnf(str, args...)
 }
 
 func (l GormLogger) Error(ctx context.Context, str string, args ...interface{}) {
 	if l.LogLevel < gormlogger.Error {
 		return
 	}
-	l.ZapLogger.Named("gorm").Sugar().Errorf(str, args...)
+	l.zapLogger(ctx).Sugar().Errorf(str, args...)
 }
 
 func (l GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
@@ -98,9 +112,9 @@ func (l GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (strin
 			formattedError := colorPallet.colorfgRed(err.Error())
 			formattedElapsed := colorifySqlLatency(elapsed, l.SlowThreshold)
 			formattedSql := colorPallet.colorfgMagenta(sql)
-			l.ZapLogger.Named("gorm").Error(fmt.Sprintf("error=%stime=%v\trows= %d\tsql=%s", formattedError, formattedElapsed, rows, formattedSql))
+			l.zapLogger(ctx).Error(fmt.Sprintf("error=%stime=%v\trows= %d\tsql=%s", formattedError, formattedElapsed, rows, formattedSql))
 		} else {
-			l.ZapLogger.Named("gorm").Error("trace",
+			l.zapLogger(ctx).Error("trace",
 				zap.Error(err),
 				zap.Duration("elapsed", elapsed),
 				zap.Int64("rows", rows),
@@ -111,10 +125,10 @@ func (l GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (strin
 		if l.LoggerMode == gin.DebugMode {
 			formattedElapsed := colorifySqlLatency(elapsed, l.SlowThreshold)
 			formattedSql := colorPallet.colorfgMagenta(sql)
-			l.ZapLogger.Named("gorm").Warn(fmt.Sprintf("time=%v\trows=%d\tsql=%s", formattedElapsed, rows, formattedSql))
+			l.zapLogger(ctx).Warn(fmt.Sprintf("time=%v\trows=%d\tsql=%s", formattedElapsed, rows, formattedSql))
 			
 		} else {
-			l.ZapLogger.Named("gorm").Debug("trace",
+			l.zapLogger(ctx).Debug("trace",
 				zap.Duration("elapsed", elapsed),
 				zap.Int64("rows", rows),
 				zap.String("sql", sql))
@@ -124,9 +138,9 @@ func (l GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (strin
 		if l.LoggerMode  == gin.DebugMode {
 			formattedElapsed := colorifySqlLatency(elapsed, l.SlowThreshold)
 			formattedSql := colorPallet.colorfgMagenta(sql)
-			l.ZapLogger.Named("gorm").Debug(fmt.Sprintf("time=%v\trows=%d\tsql=%s", formattedElapsed, rows, formattedSql))
+			l.zapLogger(ctx).Debug(fmt.Sprintf("time=%v\trows=%d\tsql=%s", formattedElapsed, rows, formattedSql))
 		} else {
-			l.ZapLogger.Named("gorm").Debug("trace",
+			l.zapLogger(ctx).Debug("trace",
 				zap.Duration("elapsed", elapsed),
 				zap.Int64("rows", rows),
 				zap.String("sql", sql))
@@ -135,6 +149,26 @@ func (l GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (strin
 }
 
 
-func SetupGormLogger(db *gorm.DB, loggerConfig loggerConfig) {
-	setupGormLogger(db, loggerConfig)
+func SetupGormLogger(db *gorm.DB, loggerConfig LoggerConfig) error {
+	gormLogger, _, err := newGormLogger(loggerConfig)
+	if err != nil {
+		return err
+	}
+	gormLogger.setup(db)
+	return nil
+}
+
+// sets l as the default gorm logger and as the logger of db
+func (l GormLogger) setup(db *gorm.DB) {
+	gormlogger.Default = l
+	if db != nil {
+		db.Logger = l
+	}
+}
+
+// MustSetupGormLogger is like SetupGormLogger but panics if the logger can't be built
+func MustSetupGormLogger(db *gorm.DB, loggerConfig LoggerConfig) {
+	if err := SetupGormLogger(db, loggerConfig); err != nil {
+		panic(err)
+	}
 }
\ No newline at end of file
@@ -2,21 +2,19 @@ package zlogger
 
 import (
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	gormv2 "gorm.io/gorm"
 )
 
-var (
-	_defaultAppLogger AppLogger
-	_defaultGinConfig gin.LoggerConfig
-)
-
 func init() {
-	SetupLoggerWithConfig("default", DEBUG_LOGGER, nil, nil)
+	MustSetupLoggerWithConfig("default", DEBUG_LOGGER, nil, nil)
 }
 
-func SetupLoggerWithConfig(serviceName string, loggerType LoggerType, db *gormv2.DB, skipRoutes []string) {
-	var loggerConfig loggerConfig
+// SetupLoggerWithConfig sets up the app, gin and gorm loggers with
+// the presets of loggerType
+func SetupLoggerWithConfig(serviceName string, loggerType LoggerType, db *gormv2.DB, skipRoutes []string) error {
+	var loggerConfig LoggerConfig
 
 	if loggerType == JSON_LOGGER {
 		loggerConfig = NewLoggerConfig(
@@ -29,24 +27,113 @@ func SetupLoggerWithConfig(serviceName string, loggerType LoggerType, db *gormv2
 			DEBUG_LOGGER,
 			zapcore.DebugLevel)
 	}
+	return SetupLoggerFromConfig(loggerConfig, db, skipRoutes)
+}
 
+// SetupLoggerFromConfig sets up the app, gin and gorm loggers with
+// a config built by NewLoggerConfig
+// the default logger set, the levels, gormlogger.Default, db.Logger and
+// gin's route printer are only replaced if every logger could be built
+func SetupLoggerFromConfig(loggerConfig LoggerConfig, db *gormv2.DB, skipRoutes []string) error {
+	_, err := SetupNamedLoggerFromConfig(DEFAULT_LOGGER_SET, loggerConfig, db, skipRoutes)
+	return err
+}
 
-  // init app logger
-	_defaultAppLogger = NewAppLogger(loggerConfig)
+// SetupNamedLoggerFromConfig sets up a logger set registered under name,
+// next to the default one. It can be found with LookupLoggerSet.
+// Only the default set is installed as gormlogger.Default and gin's route printer.
+func SetupNamedLoggerFromConfig(name string, loggerConfig LoggerConfig, db *gormv2.DB, skipRoutes []string) (*LoggerSet, error) {
+	if _, err := compileSkipRoutes(skipRoutes); err != nil {
+		return nil, &ConfigError{Logger: GIN_LOGGER, LoggerName: loggerConfig.loggerName, Err: err}
+	}
+	// identifies the loggers of this set, so they are reloaded or untracked together
+	loggerConfig.setID = _configSetID.Add(1)
+
+	loggerSet, err := newLoggerSet(name, loggerConfig, skipRoutes)
+	if err != nil {
+		// nothing else holds the loggers built so far
+		for _, tracked := range untrackLoggerSet(loggerConfig.setID) {
+			tracked.close()
+		}
+		return nil, err
+	}
 
+	// nothing global is changed before every logger is built
+	// a replaced set is not reloaded anymore, loggers still held by the app
+	// keep their outputs until LoggerSet.Close or Shutdown
+	registerLoggerSet(loggerSet)
+	if name == DEFAULT_LOGGER_SET {
+		loggerSet.gormLogger.setup(db)
+		gin.DebugPrintRouteFunc = loggerSet.ginLogger.ginDebugLogger
+	} else if db != nil {
+		db.Logger = loggerSet.gormLogger
+	}
+	return loggerSet, nil
+}
+
+func newLoggerSet(name string, loggerConfig LoggerConfig, skipRoutes []string) (*LoggerSet, error) {
+  // init app logger
+	appLogger, err := NewAppLogger(loggerConfig)
+	if err != nil {
+		return nil, err
+	}
 
   // init gorm logger
-	SetupGormLogger(db, loggerConfig)
+	gormLogger, gormLevel, err := newGormLogger(loggerConfig)
+	if err != nil {
+		return nil, err
+	}
 	
   // init gin logger
-  _defaultGinConfig = NewGinLoggerConfig(loggerConfig, skipRoutes)
+	ginLogger, err := newGinLogger(loggerConfig, skipRoutes)
+	if err != nil {
+		return nil, err
+	}
+
+	loggerSet := &LoggerSet{
+		name:         name,
+		appLogger:    appLogger,
+		ginLogger:    ginLogger,
+		gormLogger:   gormLogger,
+		loggerConfig: loggerConfig,
+		levels: map[string]zap.AtomicLevel{
+			APP_LOGGER:  loggerConfig.config.Level,
+			GIN_LOGGER:  ginLogger.level,
+			GORM_LOGGER: gormLevel,
+		},
+	}
+	return loggerSet, nil
+}
+
+// MustSetupLoggerWithConfig is like SetupLoggerWithConfig but panics on error
+func MustSetupLoggerWithConfig(serviceName string, loggerType LoggerType, db *gormv2.DB, skipRoutes []string) {
+	if err := SetupLoggerWithConfig(serviceName, loggerType, db, skipRoutes); err != nil {
+		panic(err)
+	}
+}
+
+// MustSetupLoggerFromConfig is like SetupLoggerFromConfig but panics on error
+func MustSetupLoggerFromConfig(loggerConfig LoggerConfig, db *gormv2.DB, skipRoutes []string) {
+	if err := SetupLoggerFromConfig(loggerConfig, db, skipRoutes); err != nil {
+		panic(err)
+	}
 }
 
 
 func GetAppLogger() AppLogger {
-		return _defaultAppLogger
+		return DefaultLoggerSet().GetAppLogger()
 }
 
 func GetGinConfig() gin.LoggerConfig {
-	return _defaultGinConfig
+	return DefaultLoggerSet().GetGinConfig()
+}
+
+// GetGinRecovery returns the recovery middleware of the default logger set
+func GetGinRecovery(handle gin.RecoveryFunc, options ...GinOption) gin.HandlerFunc {
+	return DefaultLoggerSet().GetGinRecovery(handle, options...)
+}
+
+// GetGinLogger returns the native gin middleware of the default logger set
+func GetGinLogger(options ...GinOption) gin.HandlerFunc {
+	return DefaultLoggerSet().GetGinLogger(options...)
 }
\ No newline at end of file
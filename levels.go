@@ -0,0 +1,161 @@
+package zlogger
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+levels of the app, gin and gorm loggers
+each logger set keeps its own levels, the package functions and
+LevelHandler act on the default logger set
+*/
+
+// GetLevelOverrides returns the per-logger-name levels of the
+// default logger set, nil if it was created without overrides.
+func GetLevelOverrides() *LevelOverrides {
+	return DefaultLoggerSet().GetLevelOverrides()
+}
+
+// GetAtomicLevel returns the level of the app, gin or gorm logger
+// of the default logger set.
+func GetAtomicLevel(logger string) (zap.AtomicLevel, bool) {
+	return DefaultLoggerSet().GetAtomicLevel(logger)
+}
+
+func (ls *LoggerSet) getLevels() map[string]zapcore.Level {
+	levels := make(map[string]zapcore.Level, len(ls.levels))
+	for logger, level := range ls.levels {
+		levels[logger] = level.Level()
+	}
+	return levels
+}
+
+/*
+* GET    /?logger=gin                    -> {"level":"info"}
+* PUT    /?logger=gin {"level":"debug"}  -> {"level":"debug"}
+* GET    /?name=payments.db              -> {"level":"debug"}
+* PUT    /?name=payments.db {"level":"debug"}
+* DELETE /?name=payments.db              -> removes the override
+* GET    /  -> {"app":"info","gin":"info","gorm":"info","overrides":{"payments.db":"debug"}}
+ */
+type levelHandler struct {
+	// looked up on every request, so a replaced set is picked up
+	loggerSet string
+}
+
+type levelPayload struct {
+	Level *zapcore.Level `json:"level"`
+}
+
+// LevelHandler returns a http.Handler to read and change the level
+// of the app, gin and gorm loggers and the per-logger-name overrides
+// of the default logger set at runtime.
+func LevelHandler() http.Handler {
+	return levelHandler{loggerSet: DEFAULT_LOGGER_SET}
+}
+
+func (h levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	query := r.URL.Query()
+
+	loggerSet, ok := LookupLoggerSet(h.loggerSet)
+	if !ok {
+		writeLevelError(w, http.StatusNotFound, "unknown logger set '"+h.loggerSet+"'")
+		return
+	}
+
+	if name := query.Get("name"); name != "" {
+		h.serveOverride(w, r, loggerSet, name)
+		return
+	}
+
+	logger := query.Get("logger")
+	if logger == "" {
+		if r.Method != http.MethodGet {
+			writeLevelError(w, http.StatusBadRequest, "query parameter 'logger' or 'name' is required")
+			return
+		}
+		h.serveAll(w, loggerSet)
+		return
+	}
+
+	level, ok := loggerSet.GetAtomicLevel(logger)
+	if !ok {
+		writeLevelError(w, http.StatusNotFound, "unknown logger '"+logger+"', expected one of "+knownLoggers(loggerSet))
+		return
+	}
+	// zap handles GET/PUT of a single level
+	level.ServeHTTP(w, r)
+}
+
+func (levelHandler) serveAll(w http.ResponseWriter, loggerSet *LoggerSet) {
+	payload := map[string]interface{}{}
+	for logger, level := range loggerSet.getLevels() {
+		payload[logger] = level
+	}
+	if levelOverrides := loggerSet.GetLevelOverrides(); levelOverrides != nil {
+		payload["overrides"] = levelOverrides.Levels()
+	}
+	json.NewEncoder(w).Encode(payload)
+}
+
+func (levelHandler) serveOverride(w http.ResponseWriter, r *http.Request, loggerSet *LoggerSet, name string) {
+	levelOverrides := loggerSet.GetLevelOverrides()
+	if levelOverrides == nil {
+		writeLevelError(w, http.StatusNotFound, "level overrides are disabled")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		level, ok := levelOverrides.Match(name)
+		if !ok {
+			writeLevelError(w, http.StatusNotFound, "no override matches '"+name+"'")
+			return
+		}
+		json.NewEncoder(w).Encode(levelPayload{Level: &level})
+	case http.MethodPut:
+		var payload levelPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Level == nil {
+			writeLevelError(w, http.StatusBadRequest, "expected a body like {\"level\":\"debug\"}")
+			return
+		}
+		levelOverrides.Set(name, *payload.Level)
+		json.NewEncoder(w).Encode(payload)
+	case http.MethodDelete:
+		levelOverrides.Remove(name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeLevelError(w, http.StatusMethodNotAllowed, "only GET, PUT and DELETE are supported")
+	}
+}
+
+// MountLevelHandler registers the LevelHandler for GET, PUT and DELETE on router.
+func MountLevelHandler(router gin.IRouter, relativePath string) {
+	handler := gin.WrapH(LevelHandler())
+	router.GET(relativePath, handler)
+	router.PUT(relativePath, handler)
+	router.DELETE(relativePath, handler)
+}
+
+func writeLevelError(w http.ResponseWriter, statusCode int, errorMsg string) {
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{"error": errorMsg})
+}
+
+func knownLoggers(loggerSet *LoggerSet) string {
+	levels := loggerSet.getLevels()
+	loggers := make([]string, 0, len(levels))
+	for logger := range levels {
+		loggers = append(loggers, logger)
+	}
+	sort.Strings(loggers)
+	names, _ := json.Marshal(loggers)
+	return string(names)
+}
@@ -0,0 +1,122 @@
+package zlogger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+/* DOCS -
+functional options for NewLoggerConfig
+applied on top of the DEBUG_LOGGER / JSON_LOGGER presets
+*/
+
+type LoggerOption func(*LoggerConfig)
+
+// WithOutputPaths replaces the outputs (default stderr), see zap.Config.OutputPaths
+func WithOutputPaths(paths ...string) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.config.OutputPaths = paths
+	}
+}
+
+// WithErrorOutputPaths replaces the outputs of zap's internal errors (default stderr)
+func WithErrorOutputPaths(paths ...string) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.config.ErrorOutputPaths = paths
+	}
+}
+
+// WithFileSink replaces the outputs with a rotating file
+func WithFileSink(fileSinkConfig FileSinkConfig) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.SetFileSink(fileSinkConfig)
+	}
+}
+
+// WithEncoding sets the encoding - "json", "console" or one added with zap.RegisterEncoder
+func WithEncoding(encoding string) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.config.Encoding = encoding
+	}
+}
+
+// WithEncoderConfig replaces the encoder config (keys, time/level/duration encoders)
+func WithEncoderConfig(encoderConfig zapcore.EncoderConfig) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.config.EncoderConfig = encoderConfig
+	}
+}
+
+// WithInitialFields adds fields to every entry of every logger
+func WithInitialFields(fields map[string]interface{}) LoggerOption {
+	return func(lc *LoggerConfig) {
+		initialFields := make(map[string]interface{}, len(lc.config.InitialFields)+len(fields))
+		for key, value := range lc.config.InitialFields {
+			initialFields[key] = value
+		}
+		for key, value := range fields {
+			initialFields[key] = value
+		}
+		lc.config.InitialFields = initialFields
+	}
+}
+
+// WithSampling logs the first `initial` entries with the same level and
+// message every second, then every `thereafter`-th one
+func WithSampling(initial int, thereafter int) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.config.Sampling = &zap.SamplingConfig{Initial: initial, Thereafter: thereafter}
+	}
+}
+
+// WithCaller enables or disables the file:line of the caller
+func WithCaller(enabled bool) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.config.DisableCaller = !enabled
+	}
+}
+
+// WithStacktrace enables or disables stacktraces on Error (Warn in DEBUG_LOGGER)
+func WithStacktrace(enabled bool) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.config.DisableStacktrace = !enabled
+	}
+}
+
+// WithLevelOverrides sets the per-logger-name levels
+func WithLevelOverrides(levels map[string]zapcore.Level) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.SetLevelOverrides(NewLevelOverrides(levels))
+	}
+}
+
+// WithAsync makes the loggers write in the background
+func WithAsync(asyncConfig AsyncConfig) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.SetAsync(asyncConfig)
+	}
+}
+
+// WithGormSlowThreshold sets the duration after which gorm queries are logged as slow (default 100ms)
+func WithGormSlowThreshold(slowThreshold time.Duration) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.gorm.slowThreshold = slowThreshold
+	}
+}
+
+// WithGormLogLevel sets the gorm log level (default gormlogger.Info)
+func WithGormLogLevel(logLevel gormlogger.LogLevel) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.gorm.logLevel = logLevel
+	}
+}
+
+// WithGormIgnoreRecordNotFoundError skips gorm.ErrRecordNotFound errors
+func WithGormIgnoreRecordNotFoundError(ignore bool) LoggerOption {
+	return func(lc *LoggerConfig) {
+		lc.gorm.ignoreRecordNotFoundError = ignore
+	}
+}
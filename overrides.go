@@ -0,0 +1,141 @@
+package zlogger
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+level overrides keyed by logger-name prefix
+"payments.db" matches the loggers "payments.db" and "payments.db.*"
+the longest matching prefix wins
+*/
+
+type LevelOverrides struct {
+	mu     sync.RWMutex
+	levels map[string]zapcore.Level
+	// lowest overridden level, InvalidLevel when empty
+	minLevel atomic.Int32
+}
+
+func NewLevelOverrides(levels map[string]zapcore.Level) *LevelOverrides {
+	o := &LevelOverrides{levels: map[string]zapcore.Level{}}
+	for prefix, level := range levels {
+		o.levels[prefix] = level
+	}
+	o.updateMinLevel()
+	return o
+}
+
+// Set overrides the level of every logger whose name starts with prefix.
+func (o *LevelOverrides) Set(prefix string, level zapcore.Level) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.levels[prefix] = level
+	o.updateMinLevel()
+}
+
+// Remove drops the override for prefix.
+func (o *LevelOverrides) Remove(prefix string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.levels, prefix)
+	o.updateMinLevel()
+}
+
+// Replace swaps the whole override table at once.
+func (o *LevelOverrides) Replace(levels map[string]zapcore.Level) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.levels = map[string]zapcore.Level{}
+	for prefix, level := range levels {
+		o.levels[prefix] = level
+	}
+	o.updateMinLevel()
+}
+
+// Levels returns a copy of the override table.
+func (o *LevelOverrides) Levels() map[string]zapcore.Level {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	levels := make(map[string]zapcore.Level, len(o.levels))
+	for prefix, level := range o.levels {
+		levels[prefix] = level
+	}
+	return levels
+}
+
+// Match returns the level of the longest prefix matching loggerName.
+func (o *LevelOverrides) Match(loggerName string) (zapcore.Level, bool) {
+	if zapcore.Level(o.minLevel.Load()) == zapcore.InvalidLevel {
+		return zapcore.InvalidLevel, false
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var matched string
+	var level zapcore.Level
+	var ok bool
+	for prefix, prefixLevel := range o.levels {
+		if len(prefix) < len(matched) || !matchLoggerName(loggerName, prefix) {
+			continue
+		}
+		matched, level, ok = prefix, prefixLevel, true
+	}
+	return level, ok
+}
+
+func (o *LevelOverrides) enabledAny(level zapcore.Level) bool {
+	return level >= zapcore.Level(o.minLevel.Load())
+}
+
+func (o *LevelOverrides) updateMinLevel() {
+	minLevel := zapcore.InvalidLevel
+	for _, level := range o.levels {
+		if level < minLevel {
+			minLevel = level
+		}
+	}
+	o.minLevel.Store(int32(minLevel))
+}
+
+func matchLoggerName(loggerName string, prefix string) bool {
+	if !strings.HasPrefix(loggerName, prefix) {
+		return false
+	}
+	return len(loggerName) == len(prefix) || loggerName[len(prefix)] == '.'
+}
+
+// levelOverrideCore consults the overrides on every Check
+// and falls back to the level of the wrapped core
+type levelOverrideCore struct {
+	zapcore.Core
+	overrides *LevelOverrides
+}
+
+func newLevelOverrideCore(core zapcore.Core, overrides *LevelOverrides) zapcore.Core {
+	return &levelOverrideCore{Core: core, overrides: overrides}
+}
+
+func (c *levelOverrideCore) Enabled(level zapcore.Level) bool {
+	return c.Core.Enabled(level) || c.overrides.enabledAny(level)
+}
+
+func (c *levelOverrideCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelOverrideCore{Core: c.Core.With(fields), overrides: c.overrides}
+}
+
+func (c *levelOverrideCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	level, ok := c.overrides.Match(entry.LoggerName)
+	if !ok {
+		return c.Core.Check(entry, checked)
+	}
+	if entry.Level >= level {
+		// the wrapped core writes without checking its own level
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
@@ -0,0 +1,143 @@
+package zlogger
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+/* DOCS -
+logger sets created by SetupLoggerFromConfig, keyed by name
+readers load the registry atomically, writers copy and swap it,
+so sets can be replaced while goroutines are logging
+*/
+
+const DEFAULT_LOGGER_SET string = "default"
+
+// LoggerSet holds the app, gin and gorm loggers built from one LoggerConfig.
+type LoggerSet struct {
+	name         string
+	appLogger    AppLogger
+	ginLogger    *ginLogger
+	gormLogger   GormLogger
+	loggerConfig LoggerConfig
+	// levels of the app, gin and gorm loggers, changed by the LevelHandler
+	levels map[string]zap.AtomicLevel
+}
+
+func (ls *LoggerSet) GetName() string {
+	return ls.name
+}
+
+func (ls *LoggerSet) GetAppLogger() AppLogger {
+	return ls.appLogger
+}
+
+func (ls *LoggerSet) GetGinConfig() gin.LoggerConfig {
+	return ls.ginLogger.loggerConfig()
+}
+
+func (ls *LoggerSet) GetGinLogger(options ...GinOption) gin.HandlerFunc {
+	return ls.ginLogger.middleware(options...)
+}
+
+// GetGinRecovery returns a gin.Recovery replacement logging through the gin logger
+// of the set, options set the route label of unmatched requests like in GetGinLogger
+func (ls *LoggerSet) GetGinRecovery(handle gin.RecoveryFunc, options ...GinOption) gin.HandlerFunc {
+	return ls.ginLogger.recovery(handle, options...)
+}
+
+func (ls *LoggerSet) GetGormLogger() GormLogger {
+	return ls.gormLogger
+}
+
+func (ls *LoggerSet) GetLoggerConfig() LoggerConfig {
+	return ls.loggerConfig
+}
+
+// GetAtomicLevel returns the level of the app, gin or gorm logger of the set.
+func (ls *LoggerSet) GetAtomicLevel(logger string) (zap.AtomicLevel, bool) {
+	level, ok := ls.levels[logger]
+	return level, ok
+}
+
+// GetLevelOverrides returns the per-logger-name levels of the set,
+// nil if it was created without overrides.
+func (ls *LoggerSet) GetLevelOverrides() *LevelOverrides {
+	return ls.loggerConfig.levelOverrides
+}
+
+// LevelHandler returns a http.Handler to change the levels of the set
+// registered under the name of ls, see LevelHandler.
+func (ls *LoggerSet) LevelHandler() http.Handler {
+	return levelHandler{loggerSet: ls.name}
+}
+
+// Close flushes the loggers of the set and closes their outputs, e.g. once
+// it was replaced by another set with the same name. Entries logged with
+// the set afterwards are dropped. Shutdown closes every set.
+func (ls *LoggerSet) Close() error {
+	var err error
+	for _, tracked := range untrackLoggerSet(ls.loggerConfig.setID) {
+		err = multierr.Append(err, tracked.close())
+	}
+	return err
+}
+
+var (
+	// serializes writers, readers only load _registry
+	_registryMu sync.Mutex
+	_registry   atomic.Pointer[map[string]*LoggerSet]
+)
+
+// returns the set registered under the same name before, nil if there is none
+func registerLoggerSet(loggerSet *LoggerSet) *LoggerSet {
+	_registryMu.Lock()
+	defer _registryMu.Unlock()
+
+	var sets map[string]*LoggerSet = map[string]*LoggerSet{}
+	if current := _registry.Load(); current != nil {
+		for name, set := range *current {
+			sets[name] = set
+		}
+	}
+	replaced := sets[loggerSet.name]
+	sets[loggerSet.name] = loggerSet
+	_registry.Store(&sets)
+	return replaced
+}
+
+// LookupLoggerSet returns the logger set registered under name.
+func LookupLoggerSet(name string) (*LoggerSet, bool) {
+	current := _registry.Load()
+	if current == nil {
+		return nil, false
+	}
+	loggerSet, ok := (*current)[name]
+	return loggerSet, ok
+}
+
+// LoggerSetNames returns the names of every registered logger set.
+func LoggerSetNames() []string {
+	current := _registry.Load()
+	if current == nil {
+		return nil
+	}
+	names := make([]string, 0, len(*current))
+	for name := range *current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultLoggerSet returns the set created by SetupLoggerWithConfig / SetupLoggerFromConfig.
+func DefaultLoggerSet() *LoggerSet {
+	loggerSet, _ := LookupLoggerSet(DEFAULT_LOGGER_SET)
+	return loggerSet
+}
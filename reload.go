@@ -0,0 +1,254 @@
+package zlogger
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+hot reload of the loggers of the default logger set
+level, level overrides, outputs, sampling and skip routes are applied in place,
+serviceName, loggerType and errorOutputs changes need a restart
+*/
+
+type trackedSkipRoutes struct {
+	setID      uint64
+	skipRoutes *ginSkipRoutes
+}
+
+var errErrorOutputsChanged = errors.New("errorOutputs changes need a restart")
+
+var (
+	_reloadMu          sync.Mutex
+	_trackedSkipRoutes []trackedSkipRoutes
+)
+
+func trackGinSkipRoutes(setID uint64, skipRoutes *ginSkipRoutes) {
+	_trackedMu.Lock()
+	defer _trackedMu.Unlock()
+	_trackedSkipRoutes = append(_trackedSkipRoutes, trackedSkipRoutes{setID: setID, skipRoutes: skipRoutes})
+}
+
+func trackedLoggersOf(setID uint64) ([]*trackedLogger, []*ginSkipRoutes) {
+	_trackedMu.Lock()
+	defer _trackedMu.Unlock()
+	var loggers []*trackedLogger
+	for _, tracked := range _trackedLoggers {
+		if tracked.loggerConfig.setID == setID {
+			loggers = append(loggers, tracked)
+		}
+	}
+	var skipRoutes []*ginSkipRoutes
+	for _, tracked := range _trackedSkipRoutes {
+		if tracked.setID == setID {
+			skipRoutes = append(skipRoutes, tracked.skipRoutes)
+		}
+	}
+	return loggers, skipRoutes
+}
+
+// ApplySettings applies the level, level overrides, outputs, sampling and
+// skip routes of settings to the running app, gin and gorm loggers.
+func ApplySettings(settings Settings) error {
+	return applySettings(nil, settings)
+}
+
+// only the parts that differ from previous are applied, everything if previous is nil
+func applySettings(previous *Settings, settings Settings) error {
+	newConfig, err := settings.LoggerConfig()
+	if err != nil {
+		return err
+	}
+
+	_reloadMu.Lock()
+	defer _reloadMu.Unlock()
+	loggers, skipRoutes := trackedLoggersOf(DefaultLoggerSet().loggerConfig.setID)
+
+	for _, tracked := range loggers {
+		// zap's error output is set on the logger, not on its core
+		if !reflect.DeepEqual(tracked.loggerConfig.config.ErrorOutputPaths, newConfig.config.ErrorOutputPaths) {
+			return &ConfigError{Logger: "reload", LoggerName: tracked.loggerConfig.loggerName, Err: errErrorOutputsChanged}
+		}
+	}
+
+	if previous == nil ||
+		!reflect.DeepEqual(previous.Outputs, settings.Outputs) ||
+		!reflect.DeepEqual(previous.Sampling, settings.Sampling) {
+		if err := swapCores(loggers, newConfig); err != nil {
+			return err
+		}
+	}
+
+	if previous == nil || previous.Level != settings.Level || previous.LoggerType != settings.LoggerType {
+		for _, tracked := range loggers {
+			// app and lib share a level, gin and gorm have their own
+			tracked.loggerConfig.config.Level.SetLevel(newConfig.loggerLevel)
+			tracked.loggerConfig.loggerLevel = newConfig.loggerLevel
+		}
+	}
+
+	if previous == nil || !reflect.DeepEqual(previous.LevelOverrides, settings.LevelOverrides) {
+		levels := newConfig.levelOverrides.Levels()
+		for _, tracked := range loggers {
+			if tracked.loggerConfig.levelOverrides != nil {
+				tracked.loggerConfig.levelOverrides.Replace(levels)
+			}
+		}
+	}
+
+	if previous == nil || !reflect.DeepEqual(previous.SkipRoutes, settings.SkipRoutes) {
+		for _, sr := range skipRoutes {
+			if err := sr.set(settings.SkipRoutes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// every core is built before any is swapped, so a bad output changes nothing
+func swapCores(loggers []*trackedLogger, newConfig LoggerConfig) error {
+	cores := make([]zapcore.Core, len(loggers))
+	outputs := make([]*coreOutputs, len(loggers))
+	configs := make([]LoggerConfig, len(loggers))
+	for i, tracked := range loggers {
+		configs[i] = tracked.loggerConfig
+		configs[i].config.OutputPaths = newConfig.config.OutputPaths
+		configs[i].config.Sampling = newConfig.config.Sampling
+
+		core, coreOutputs, err := rebuildZapCore(&configs[i], tracked.queue)
+		if err != nil {
+			for _, built := range outputs[:i] {
+				built.close(nil)
+			}
+			return &ConfigError{Logger: "reload", LoggerName: configs[i].loggerName, Err: err}
+		}
+		cores[i] = core
+		outputs[i] = coreOutputs
+	}
+
+	for i, tracked := range loggers {
+		oldCore := tracked.reloadable.swap(cores[i])
+		tracked.loggerConfig = configs[i]
+		oldCore.Sync()
+		// entries checked against the old core while it is closed are written by the new one
+		tracked.outputs.close(tracked.reloadable.current)
+		tracked.outputs = outputs[i]
+	}
+	return nil
+}
+
+type SettingsWatcher struct {
+	path     string
+	interval time.Duration
+
+	mu      sync.Mutex
+	applied *Settings
+	modTime time.Time
+	size    int64
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// WatchSettingsFile applies the settings file at path (with the ZLOGGER_* env
+// vars on top) to the running loggers, then again whenever the file changes,
+// checked every interval, or the process receives SIGHUP.
+func WatchSettingsFile(path string, interval time.Duration) (*SettingsWatcher, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	w := &SettingsWatcher{
+		path:     path,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+	go w.run()
+	return w, nil
+}
+
+// Reload re-reads the file and applies what changed since the last reload.
+// The running loggers are left untouched if the file is invalid.
+func (w *SettingsWatcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return err
+	}
+	settings, err := readSettingsFile(w.path)
+	if err != nil {
+		return err
+	}
+	if err := settings.applyEnv(); err != nil {
+		return err
+	}
+	if err := applySettings(w.applied, settings); err != nil {
+		return err
+	}
+	if w.applied != nil && (w.applied.ServiceName != settings.ServiceName || w.applied.LoggerType != settings.LoggerType) {
+		GetAppLogger().Named("lib").Warn("serviceName and loggerType changes need a restart",
+			zap.String("file", w.path))
+	}
+	w.applied = &settings
+	w.modTime, w.size = info.ModTime(), info.Size()
+	return nil
+}
+
+func (w *SettingsWatcher) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return !info.ModTime().Equal(w.modTime) || info.Size() != w.size
+}
+
+func (w *SettingsWatcher) run() {
+	defer close(w.done)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-signals:
+		case <-ticker.C:
+			if !w.changed() {
+				continue
+			}
+		}
+		if err := w.Reload(); err != nil {
+			GetAppLogger().Named("lib").Error("unable to reload logger settings, keeping the previous ones",
+				zap.String("file", w.path),
+				zap.Error(err))
+		}
+	}
+}
+
+// Close stops watching the file.
+func (w *SettingsWatcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.stop)
+		<-w.done
+	})
+}
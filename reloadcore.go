@@ -0,0 +1,164 @@
+package zlogger
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+core that can be swapped while loggers are in use
+children created by With re-apply their fields to the new core
+entries already checked against the old core are written to its outputs
+until they are closed, then to the new core
+*/
+
+var errOutputsClosed = errors.New("zlogger: the outputs of the logger are closed")
+
+type versionedCore struct {
+	core    zapcore.Core
+	version uint64
+}
+
+type reloadableRoot struct {
+	current atomic.Pointer[versionedCore]
+}
+
+type reloadableCore struct {
+	root   *reloadableRoot
+	fields []zapcore.Field
+	// root core + fields, rebuilt when the root is swapped
+	cached atomic.Pointer[versionedCore]
+}
+
+func newReloadableCore(core zapcore.Core) *reloadableCore {
+	root := &reloadableRoot{}
+	root.current.Store(&versionedCore{core: core})
+	return &reloadableCore{root: root}
+}
+
+// swap replaces the core of this logger and every child of it
+func (c *reloadableCore) swap(core zapcore.Core) zapcore.Core {
+	for {
+		current := c.root.current.Load()
+		if c.root.current.CompareAndSwap(current, &versionedCore{core: core, version: current.version + 1}) {
+			return current.core
+		}
+	}
+}
+
+// the current root core, without the fields of c
+func (c *reloadableCore) current() zapcore.Core {
+	return c.root.current.Load().core
+}
+
+func (c *reloadableCore) core() zapcore.Core {
+	current := c.root.current.Load()
+	if len(c.fields) == 0 {
+		return current.core
+	}
+	if cached := c.cached.Load(); cached != nil && cached.version == current.version {
+		return cached.core
+	}
+	withFields := &versionedCore{core: current.core.With(c.fields), version: current.version}
+	c.cached.Store(withFields)
+	return withFields.core
+}
+
+func (c *reloadableCore) Enabled(level zapcore.Level) bool {
+	return c.core().Enabled(level)
+}
+
+func (c *reloadableCore) With(fields []zapcore.Field) zapcore.Core {
+	childFields := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	childFields = append(childFields, c.fields...)
+	childFields = append(childFields, fields...)
+	return &reloadableCore{root: c.root, fields: childFields}
+}
+
+func (c *reloadableCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return c.core().Check(entry, checked)
+}
+
+func (c *reloadableCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.core().Write(entry, fields)
+}
+
+func (c *reloadableCore) Sync() error {
+	return c.core().Sync()
+}
+
+// outputs of a core, closed on reload once no entry is being written to them
+type coreOutputs struct {
+	mu     sync.RWMutex
+	closed bool
+	// the core entries checked against the closed core are written by,
+	// nil when the logger is not reloaded anymore
+	replacement func() zapcore.Core
+	closeSinks  func()
+}
+
+// close waits for the writes in progress, later writes go to replacement
+func (o *coreOutputs) close(replacement func() zapcore.Core) {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return
+	}
+	o.closed = true
+	o.replacement = replacement
+	o.mu.Unlock()
+	o.closeSinks()
+}
+
+// outputCore is the core writing to the outputs,
+// fields keeps what was added by With to re-apply it to the replacement
+type outputCore struct {
+	zapcore.Core
+	outputs *coreOutputs
+	fields  []zapcore.Field
+}
+
+func newOutputCore(core zapcore.Core, closeSinks func()) (zapcore.Core, *coreOutputs) {
+	outputs := &coreOutputs{closeSinks: closeSinks}
+	return &outputCore{Core: core, outputs: outputs}, outputs
+}
+
+func (c *outputCore) With(fields []zapcore.Field) zapcore.Core {
+	childFields := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	childFields = append(childFields, c.fields...)
+	childFields = append(childFields, fields...)
+	return &outputCore{Core: c.Core.With(fields), outputs: c.outputs, fields: childFields}
+}
+
+func (c *outputCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *outputCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	c.outputs.mu.RLock()
+	if !c.outputs.closed {
+		defer c.outputs.mu.RUnlock()
+		return c.Core.Write(entry, fields)
+	}
+	replacement := c.outputs.replacement
+	c.outputs.mu.RUnlock()
+	if replacement == nil {
+		return errOutputsClosed
+	}
+	return replacement().With(c.fields).Write(entry, fields)
+}
+
+func (c *outputCore) Sync() error {
+	c.outputs.mu.RLock()
+	defer c.outputs.mu.RUnlock()
+	if c.outputs.closed {
+		return nil
+	}
+	return c.Core.Sync()
+}
@@ -0,0 +1,194 @@
+package zlogger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+/* DOCS -
+request id middleware
+an incoming id is reused, otherwise one is generated, echoed in the response
+and attached to the gin access entry, the request logger, the *Ctx app
+methods and the gorm entries of queries run with the request context
+*/
+
+type RequestIDGenerator string
+
+const (
+	// UUIDV4 generates random RFC 4122 uuids (default)
+	UUIDV4 RequestIDGenerator = "uuidv4"
+	// ULID generates lexicographically sortable ids, ms timestamp + 80 random bits
+	ULID RequestIDGenerator = "ulid"
+	// KSUID generates k-sortable ids, s timestamp + 128 random bits
+	KSUID RequestIDGenerator = "ksuid"
+)
+
+// gin.Context key of the request id
+const REQUEST_ID_KEY string = "zlogger.requestId"
+
+// longest incoming id that is reused, longer ones are replaced
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+type RequestIDConfig struct {
+	// Header is read from the request and written to the response (default X-Request-ID)
+	Header string
+	// Generator creates ids for requests without one (default UUIDV4)
+	Generator RequestIDGenerator
+}
+
+func (rc RequestIDConfig) withDefaults() RequestIDConfig {
+	if rc.Header == "" {
+		rc.Header = REQUEST_ID_HEADER
+	}
+	if rc.Generator == "" {
+		rc.Generator = UUIDV4
+	}
+	return rc
+}
+
+// RequestID returns a gin middleware that reads the request id from
+// requestIDConfig.Header or generates one, and echoes it in the response.
+// Use it before RequestLogger and the gin logger.
+func RequestID(requestIDConfig RequestIDConfig) gin.HandlerFunc {
+	requestIDConfig = requestIDConfig.withDefaults()
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDConfig.Header)
+		if !validRequestID(requestID) {
+			requestID = NewRequestID(requestIDConfig.Generator)
+			c.Request.Header.Set(requestIDConfig.Header, requestID)
+		}
+		c.Header(requestIDConfig.Header, requestID)
+		c.Set(REQUEST_ID_KEY, requestID)
+		c.Request = c.Request.WithContext(ContextWithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
+
+// ContextWithRequestID returns a copy of ctx carrying requestID, also
+// attached as the "requestId" field by the *Ctx methods and the gorm logger.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = ContextWithFields(ctx, zap.String("requestId", requestID))
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request id carried by ctx, or "".
+// A *gin.Context can be passed as well.
+func RequestIDFromContext(ctx context.Context) string {
+	if c, ok := ctx.(*gin.Context); ok {
+		if requestID := c.GetString(REQUEST_ID_KEY); requestID != "" {
+			return requestID
+		}
+		if c.Request == nil {
+			return ""
+		}
+		ctx = c.Request.Context()
+	}
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// only printable ascii is reused, so a client can't forge log lines
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < '!' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewRequestID returns a new id built by generator, a UUIDV4 for unknown generators
+func NewRequestID(generator RequestIDGenerator) string {
+	switch generator {
+	case ULID:
+		return newULID(time.Now())
+	case KSUID:
+		return newKSUID(time.Now())
+	default:
+		return newUUIDV4()
+	}
+}
+
+func randomBytes(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+}
+
+func newUUIDV4() string {
+	var uuid [16]byte
+	randomBytes(uuid[:])
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], uuid[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], uuid[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], uuid[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], uuid[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], uuid[10:])
+	return string(buf[:])
+}
+
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// 48 bit unix ms timestamp + 80 random bits, 26 crockford base32 chars
+func newULID(now time.Time) string {
+	var ulid [16]byte
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(now.UnixMilli()))
+	copy(ulid[:6], ms[2:])
+	randomBytes(ulid[6:])
+
+	// 128 bits as 26 5-bit groups, the first group only holds 3 bits
+	hi := binary.BigEndian.Uint64(ulid[:8])
+	lo := binary.BigEndian.Uint64(ulid[8:])
+	var buf [26]byte
+	for i := 25; i >= 0; i-- {
+		buf[i] = crockfordBase32[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// seconds since the KSUID epoch (2014-05-13)
+const ksuidEpoch = 1400000000
+
+// 32 bit timestamp + 128 random bits, 27 base62 chars
+func newKSUID(now time.Time) string {
+	var ksuid [20]byte
+	binary.BigEndian.PutUint32(ksuid[:4], uint32(now.Unix()-ksuidEpoch))
+	randomBytes(ksuid[4:])
+
+	n := new(big.Int).SetBytes(ksuid[:])
+	radix := big.NewInt(62)
+	mod := new(big.Int)
+	var buf [27]byte
+	for i := 26; i >= 0; i-- {
+		n.DivMod(n, radix, mod)
+		buf[i] = base62[mod.Int64()]
+	}
+	return string(buf[:])
+}
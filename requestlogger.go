@@ -0,0 +1,99 @@
+package zlogger
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+/* DOCS -
+per request app logger, created by the RequestLogger middleware
+stored in the gin.Context and in the context.Context of the request,
+so it can be retrieved anywhere down the call chain
+*/
+
+// gin.Context key of the request logger
+const REQUEST_LOGGER_KEY string = "zlogger.requestLogger"
+
+const REQUEST_ID_HEADER string = "X-Request-ID"
+
+type requestLoggerKey struct{}
+
+// RequestLogger returns a gin middleware creating a child of appLogger for
+// every request, with the request id, method, route, client ip, user agent
+// and trace ids bound to it. GetAppLogger() is used when appLogger is nil.
+func RequestLogger(appLogger AppLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		baseLogger := appLogger
+		if baseLogger == nil {
+			baseLogger = GetAppLogger()
+		}
+		requestLogger := baseLogger.With(requestFields(c)...)
+
+		c.Set(REQUEST_LOGGER_KEY, requestLogger)
+		c.Request = c.Request.WithContext(ContextWithLogger(c.Request.Context(), requestLogger))
+		c.Next()
+	}
+}
+
+// RequestLogger is RequestLogger with the app logger of the set
+func (ls *LoggerSet) RequestLogger() gin.HandlerFunc {
+	return RequestLogger(ls.appLogger)
+}
+
+// the request id and trace fields are also carried by the request context,
+// the *Ctx methods of the request logger skip them as they are bound here
+func requestFields(c *gin.Context) []zap.Field {
+	fields := make([]zap.Field, 0, 5)
+	requestID := RequestIDFromContext(c)
+	if requestID == "" {
+		requestID = c.GetHeader(REQUEST_ID_HEADER)
+	}
+	if requestID != "" {
+		fields = append(fields, zap.String("requestId", requestID))
+	}
+	fields = append(fields,
+		zap.String("requestMethod", c.Request.Method),
+		zap.String("route", c.FullPath()),
+		zap.String("clientIP", c.ClientIP()),
+		zap.String("userAgent", c.Request.UserAgent()),
+	)
+	return append(fields, traceFields(c.Request.Context())...)
+}
+
+// ContextWithLogger returns a copy of ctx carrying appLogger, read back by FromContext
+func ContextWithLogger(ctx context.Context, appLogger AppLogger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestLoggerKey{}, appLogger)
+}
+
+// FromGinContext returns the request logger stored by the RequestLogger
+// middleware, or GetAppLogger() if there is none.
+func FromGinContext(c *gin.Context) AppLogger {
+	if c != nil {
+		if requestLogger, ok := c.Value(REQUEST_LOGGER_KEY).(AppLogger); ok {
+			return requestLogger
+		}
+		if c.Request != nil {
+			return FromContext(c.Request.Context())
+		}
+	}
+	return GetAppLogger()
+}
+
+// FromContext returns the request logger carried by ctx, or GetAppLogger()
+// if there is none. A *gin.Context can be passed as well.
+func FromContext(ctx context.Context) AppLogger {
+	if c, ok := ctx.(*gin.Context); ok {
+		return FromGinContext(c)
+	}
+	if ctx != nil {
+		if requestLogger, ok := ctx.Value(requestLoggerKey{}).(AppLogger); ok {
+			return requestLogger
+		}
+	}
+	return GetAppLogger()
+}
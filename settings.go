@@ -0,0 +1,368 @@
+package zlogger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v2"
+	gormv2 "gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+/* DOCS -
+logger settings read from ZLOGGER_* env vars or a YAML/JSON file
+
+serviceName: payments
+loggerType: json
+level: info
+levelOverrides:
+  payments.db: debug
+outputs: [stderr, "rotate:///var/log/payments.log?maxSize=100"]
+skipRoutes: [/health]
+sampling: {initial: 100, thereafter: 10}
+gorm: {slowThreshold: 200ms, logLevel: warn, ignoreRecordNotFoundError: true}
+*/
+
+const (
+	ENV_CONFIG_FILE                  string = "ZLOGGER_CONFIG_FILE"
+	ENV_SERVICE_NAME                 string = "ZLOGGER_SERVICE_NAME"
+	ENV_LOGGER_TYPE                  string = "ZLOGGER_TYPE"
+	ENV_LEVEL                        string = "ZLOGGER_LEVEL"
+	ENV_LEVEL_OVERRIDES              string = "ZLOGGER_LEVEL_OVERRIDES" // payments.db=debug,payments.api=warn
+	ENV_OUTPUTS                      string = "ZLOGGER_OUTPUTS"         // comma separated
+	ENV_ERROR_OUTPUTS                string = "ZLOGGER_ERROR_OUTPUTS"   // comma separated
+	ENV_SKIP_ROUTES                  string = "ZLOGGER_SKIP_ROUTES"     // comma separated
+	ENV_SAMPLING_INITIAL             string = "ZLOGGER_SAMPLING_INITIAL"
+	ENV_SAMPLING_THEREAFTER          string = "ZLOGGER_SAMPLING_THEREAFTER"
+	ENV_GORM_SLOW_THRESHOLD          string = "ZLOGGER_GORM_SLOW_THRESHOLD"
+	ENV_GORM_LOG_LEVEL               string = "ZLOGGER_GORM_LOG_LEVEL"
+	ENV_GORM_IGNORE_RECORD_NOT_FOUND string = "ZLOGGER_GORM_IGNORE_RECORD_NOT_FOUND"
+)
+
+type Settings struct {
+	ServiceName    string            `json:"serviceName" yaml:"serviceName"`
+	LoggerType     string            `json:"loggerType" yaml:"loggerType"`
+	Level          string            `json:"level" yaml:"level"`
+	LevelOverrides map[string]string `json:"levelOverrides" yaml:"levelOverrides"`
+	Outputs        []string          `json:"outputs" yaml:"outputs"`
+	ErrorOutputs   []string          `json:"errorOutputs" yaml:"errorOutputs"`
+	SkipRoutes     []string          `json:"skipRoutes" yaml:"skipRoutes"`
+	Sampling       *SamplingSettings `json:"sampling" yaml:"sampling"`
+	Gorm           GormSettings      `json:"gorm" yaml:"gorm"`
+
+	// env var each key was read from, to name it in errors
+	sources map[string]string
+}
+
+type SamplingSettings struct {
+	Initial    int `json:"initial" yaml:"initial"`
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+}
+
+type GormSettings struct {
+	// SlowThreshold is a duration like "200ms"
+	SlowThreshold string `json:"slowThreshold" yaml:"slowThreshold"`
+	// LogLevel is one of silent, error, warn, info
+	LogLevel                  string `json:"logLevel" yaml:"logLevel"`
+	IgnoreRecordNotFoundError bool   `json:"ignoreRecordNotFoundError" yaml:"ignoreRecordNotFoundError"`
+}
+
+// SettingsError names the key (or env var) holding an invalid value.
+type SettingsError struct {
+	Key   string
+	Value string
+	Err   error
+}
+
+func (e *SettingsError) Error() string {
+	return fmt.Sprintf("zlogger: invalid value %q for %s: %v", e.Value, e.Key, e.Err)
+}
+
+func (e *SettingsError) Unwrap() error {
+	return e.Err
+}
+
+// LoadSettings reads the file named by ZLOGGER_CONFIG_FILE (if set)
+// and applies the ZLOGGER_* env vars on top of it.
+func LoadSettings() (Settings, error) {
+	var settings Settings
+	if path := os.Getenv(ENV_CONFIG_FILE); path != "" {
+		var err error
+		if settings, err = readSettingsFile(path); err != nil {
+			return settings, err
+		}
+	}
+	if err := settings.applyEnv(); err != nil {
+		return settings, err
+	}
+	return settings, settings.Validate()
+}
+
+// LoadSettingsFromEnv reads the ZLOGGER_* env vars.
+func LoadSettingsFromEnv() (Settings, error) {
+	var settings Settings
+	if err := settings.applyEnv(); err != nil {
+		return settings, err
+	}
+	return settings, settings.Validate()
+}
+
+// LoadSettingsFromFile reads a .yaml, .yml or .json file.
+func LoadSettingsFromFile(path string) (Settings, error) {
+	settings, err := readSettingsFile(path)
+	if err != nil {
+		return settings, err
+	}
+	return settings, settings.Validate()
+}
+
+// unknown keys are reported by the decoders
+func readSettingsFile(path string) (Settings, error) {
+	var settings Settings
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return settings, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.UnmarshalStrict(content, &settings)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&settings)
+	default:
+		return settings, fmt.Errorf("zlogger: unsupported config file %q, expected .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return settings, fmt.Errorf("zlogger: unable to parse %q: %w", path, err)
+	}
+	return settings, nil
+}
+
+func (s *Settings) applyEnv() error {
+	setString := func(envKey string, key string, target *string) {
+		if value, ok := os.LookupEnv(envKey); ok {
+			*target = value
+			s.setSource(key, envKey)
+		}
+	}
+	setList := func(envKey string, key string, target *[]string) {
+		if value, ok := os.LookupEnv(envKey); ok {
+			*target = splitList(value)
+			s.setSource(key, envKey)
+		}
+	}
+
+	setString(ENV_SERVICE_NAME, "serviceName", &s.ServiceName)
+	setString(ENV_LOGGER_TYPE, "loggerType", &s.LoggerType)
+	setString(ENV_LEVEL, "level", &s.Level)
+	setList(ENV_OUTPUTS, "outputs", &s.Outputs)
+	setList(ENV_ERROR_OUTPUTS, "errorOutputs", &s.ErrorOutputs)
+	setList(ENV_SKIP_ROUTES, "skipRoutes", &s.SkipRoutes)
+	setString(ENV_GORM_SLOW_THRESHOLD, "gorm.slowThreshold", &s.Gorm.SlowThreshold)
+	setString(ENV_GORM_LOG_LEVEL, "gorm.logLevel", &s.Gorm.LogLevel)
+
+	if value, ok := os.LookupEnv(ENV_LEVEL_OVERRIDES); ok {
+		s.LevelOverrides = map[string]string{}
+		for _, pair := range splitList(value) {
+			name, level, found := strings.Cut(pair, "=")
+			if !found {
+				return &SettingsError{Key: ENV_LEVEL_OVERRIDES, Value: pair, Err: fmt.Errorf("expected name=level")}
+			}
+			s.LevelOverrides[strings.TrimSpace(name)] = strings.TrimSpace(level)
+		}
+		s.setSource("levelOverrides", ENV_LEVEL_OVERRIDES)
+	}
+
+	samplingEnv := []struct {
+		envKey string
+		key    string
+		target func(*SamplingSettings) *int
+	}{
+		{ENV_SAMPLING_INITIAL, "sampling.initial", func(ss *SamplingSettings) *int { return &ss.Initial }},
+		{ENV_SAMPLING_THEREAFTER, "sampling.thereafter", func(ss *SamplingSettings) *int { return &ss.Thereafter }},
+	}
+	for _, env := range samplingEnv {
+		value, ok := os.LookupEnv(env.envKey)
+		if !ok {
+			continue
+		}
+		parsed, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return &SettingsError{Key: env.envKey, Value: value, Err: err}
+		}
+		if s.Sampling == nil {
+			s.Sampling = &SamplingSettings{}
+		}
+		*env.target(s.Sampling) = parsed
+		s.setSource(env.key, env.envKey)
+	}
+
+	if value, ok := os.LookupEnv(ENV_GORM_IGNORE_RECORD_NOT_FOUND); ok {
+		parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return &SettingsError{Key: ENV_GORM_IGNORE_RECORD_NOT_FOUND, Value: value, Err: err}
+		}
+		s.Gorm.IgnoreRecordNotFoundError = parsed
+		s.setSource("gorm.ignoreRecordNotFoundError", ENV_GORM_IGNORE_RECORD_NOT_FOUND)
+	}
+	return nil
+}
+
+func (s *Settings) setSource(key string, envKey string) {
+	if s.sources == nil {
+		s.sources = map[string]string{}
+	}
+	s.sources[key] = envKey
+}
+
+// keys read from the environment are reported with their env var name
+func (s Settings) keyName(key string) string {
+	if envKey, ok := s.sources[key]; ok {
+		return envKey
+	}
+	return key
+}
+
+// Validate checks every value and returns a *SettingsError for the first invalid one.
+func (s Settings) Validate() error {
+	_, err := s.LoggerConfig()
+	return err
+}
+
+// LoggerConfig builds a LoggerConfig from the settings, options are applied last.
+func (s Settings) LoggerConfig(options ...LoggerOption) (LoggerConfig, error) {
+	loggerType := LoggerType(strings.ToLower(s.LoggerType))
+	switch loggerType {
+	case "":
+		loggerType = DEBUG_LOGGER
+	case DEBUG_LOGGER, JSON_LOGGER:
+	default:
+		return LoggerConfig{}, &SettingsError{Key: s.keyName("loggerType"), Value: s.LoggerType, Err: fmt.Errorf("expected %q or %q", DEBUG_LOGGER, JSON_LOGGER)}
+	}
+
+	// same defaults as SetupLoggerWithConfig
+	level := zapcore.DebugLevel
+	if loggerType == JSON_LOGGER {
+		level = zapcore.InfoLevel
+	}
+	if s.Level != "" {
+		parsed, err := zapcore.ParseLevel(s.Level)
+		if err != nil {
+			return LoggerConfig{}, &SettingsError{Key: s.keyName("level"), Value: s.Level, Err: err}
+		}
+		level = parsed
+	}
+
+	levelOverrides, err := s.levelOverrides()
+	if err != nil {
+		return LoggerConfig{}, err
+	}
+	var settingsOptions []LoggerOption = []LoggerOption{WithLevelOverrides(levelOverrides)}
+
+	if len(s.Outputs) > 0 {
+		settingsOptions = append(settingsOptions, WithOutputPaths(s.Outputs...))
+	}
+	if len(s.ErrorOutputs) > 0 {
+		settingsOptions = append(settingsOptions, WithErrorOutputPaths(s.ErrorOutputs...))
+	}
+	if s.Sampling != nil {
+		if s.Sampling.Initial < 0 {
+			return LoggerConfig{}, &SettingsError{Key: s.keyName("sampling.initial"), Value: strconv.Itoa(s.Sampling.Initial), Err: fmt.Errorf("must not be negative")}
+		}
+		if s.Sampling.Thereafter < 0 {
+			return LoggerConfig{}, &SettingsError{Key: s.keyName("sampling.thereafter"), Value: strconv.Itoa(s.Sampling.Thereafter), Err: fmt.Errorf("must not be negative")}
+		}
+		settingsOptions = append(settingsOptions, WithSampling(s.Sampling.Initial, s.Sampling.Thereafter))
+	}
+
+	if _, err := compileSkipRoutes(s.SkipRoutes); err != nil {
+		return LoggerConfig{}, &SettingsError{Key: s.keyName("skipRoutes"), Value: strings.Join(s.SkipRoutes, ","), Err: err}
+	}
+
+	if s.Gorm.SlowThreshold != "" {
+		slowThreshold, err := time.ParseDuration(s.Gorm.SlowThreshold)
+		if err != nil {
+			return LoggerConfig{}, &SettingsError{Key: s.keyName("gorm.slowThreshold"), Value: s.Gorm.SlowThreshold, Err: err}
+		}
+		settingsOptions = append(settingsOptions, WithGormSlowThreshold(slowThreshold))
+	}
+	if s.Gorm.LogLevel != "" {
+		logLevel, err := parseGormLogLevel(s.Gorm.LogLevel)
+		if err != nil {
+			return LoggerConfig{}, &SettingsError{Key: s.keyName("gorm.logLevel"), Value: s.Gorm.LogLevel, Err: err}
+		}
+		settingsOptions = append(settingsOptions, WithGormLogLevel(logLevel))
+	}
+	settingsOptions = append(settingsOptions, WithGormIgnoreRecordNotFoundError(s.Gorm.IgnoreRecordNotFoundError))
+
+	return NewLoggerConfig(s.ServiceName, loggerType, level, append(settingsOptions, options...)...), nil
+}
+
+func (s Settings) levelOverrides() (map[string]zapcore.Level, error) {
+	levelOverrides := make(map[string]zapcore.Level, len(s.LevelOverrides))
+	names := make([]string, 0, len(s.LevelOverrides))
+	for name := range s.LevelOverrides {
+		names = append(names, name)
+	}
+	// report the same key on every run
+	sort.Strings(names)
+	for _, name := range names {
+		key := s.keyName("levelOverrides")
+		if key == "levelOverrides" {
+			key = "levelOverrides." + name
+		}
+		if name == "" {
+			return nil, &SettingsError{Key: key, Value: s.LevelOverrides[name], Err: fmt.Errorf("logger name must not be empty")}
+		}
+		level, err := zapcore.ParseLevel(s.LevelOverrides[name])
+		if err != nil {
+			return nil, &SettingsError{Key: key, Value: s.LevelOverrides[name], Err: err}
+		}
+		levelOverrides[name] = level
+	}
+	return levelOverrides, nil
+}
+
+func parseGormLogLevel(text string) (gormlogger.LogLevel, error) {
+	switch strings.ToLower(text) {
+	case "silent":
+		return gormlogger.Silent, nil
+	case "error":
+		return gormlogger.Error, nil
+	case "warn":
+		return gormlogger.Warn, nil
+	case "info":
+		return gormlogger.Info, nil
+	}
+	return 0, fmt.Errorf("expected one of silent, error, warn, info")
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// SetupLoggerFromSettings sets up the app, gin and gorm loggers from settings
+// loaded by LoadSettings, LoadSettingsFromEnv or LoadSettingsFromFile.
+func SetupLoggerFromSettings(settings Settings, db *gormv2.DB) error {
+	loggerConfig, err := settings.LoggerConfig()
+	if err != nil {
+		return err
+	}
+	return SetupLoggerFromConfig(loggerConfig, db, settings.SkipRoutes)
+}
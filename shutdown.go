@@ -0,0 +1,147 @@
+package zlogger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"syscall"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+/* DOCS -
+every logger built by the package is tracked,
+so Shutdown can flush all of them at process exit
+*/
+
+type trackedLogger struct {
+	logger *zap.Logger
+	// config the logger was built with, updated on reload
+	loggerConfig LoggerConfig
+	// set for async loggers only
+	queue      *asyncQueue
+	reloadable *reloadableCore
+	// outputs of the current core, replaced on reload
+	outputs *coreOutputs
+	// the error output is kept across reloads
+	closeErrSink func()
+}
+
+var (
+	_trackedMu       sync.Mutex
+	_trackedLoggers  []*trackedLogger
+	_trackedSamplers []*requestSampler
+)
+
+func trackLogger(logger *zap.Logger, loggerConfig LoggerConfig, queue *asyncQueue, reloadable *reloadableCore, outputs *coreOutputs, closeErrSink func()) {
+	_trackedMu.Lock()
+	defer _trackedMu.Unlock()
+	_trackedLoggers = append(_trackedLoggers, &trackedLogger{
+		logger:       logger,
+		loggerConfig: loggerConfig,
+		queue:        queue,
+		reloadable:   reloadable,
+		outputs:      outputs,
+		closeErrSink: closeErrSink,
+	})
+}
+
+func trackRequestSampler(sampler *requestSampler) {
+	_trackedMu.Lock()
+	defer _trackedMu.Unlock()
+	_trackedSamplers = append(_trackedSamplers, sampler)
+}
+
+// forgets the loggers of a logger set, stopping their async writers
+// their outputs are closed by the caller
+func untrackLoggerSet(setID uint64) []*trackedLogger {
+	_trackedMu.Lock()
+	var untracked []*trackedLogger
+	loggers := _trackedLoggers[:0]
+	for _, tracked := range _trackedLoggers {
+		if tracked.loggerConfig.setID == setID {
+			untracked = append(untracked, tracked)
+		} else {
+			loggers = append(loggers, tracked)
+		}
+	}
+	for i := len(loggers); i < len(_trackedLoggers); i++ {
+		_trackedLoggers[i] = nil
+	}
+	_trackedLoggers = loggers
+
+	skipRoutes := _trackedSkipRoutes[:0]
+	for _, tracked := range _trackedSkipRoutes {
+		if tracked.setID != setID {
+			skipRoutes = append(skipRoutes, tracked)
+		}
+	}
+	for i := len(skipRoutes); i < len(_trackedSkipRoutes); i++ {
+		_trackedSkipRoutes[i] = trackedSkipRoutes{}
+	}
+	_trackedSkipRoutes = skipRoutes
+	_trackedMu.Unlock()
+
+	for _, tracked := range untracked {
+		if tracked.queue != nil {
+			tracked.queue.close()
+		}
+	}
+	return untracked
+}
+
+// flushes the logger, stops its async writer and closes its outputs
+func (tracked *trackedLogger) close() error {
+	var err error
+	// Sync drains the async queue before syncing the outputs
+	if syncErr := tracked.logger.Sync(); syncErr != nil && !isIgnorableSyncError(syncErr) {
+		err = syncErr
+	}
+	if tracked.queue != nil {
+		tracked.queue.close()
+	}
+	tracked.outputs.close(nil)
+	tracked.closeErrSink()
+	return err
+}
+
+// Shutdown flushes every logger created by the package (app, gin, gorm and
+// the internal lib loggers, replaced logger sets included), reports the
+// requests suppressed by gin sampling, stops the async writers and closes
+// the outputs of the loggers.
+// It returns ctx.Err() if ctx is done before everything is flushed.
+func Shutdown(ctx context.Context) error {
+	_trackedMu.Lock()
+	loggers := _trackedLoggers
+	_trackedLoggers = nil
+	samplers := _trackedSamplers
+	_trackedSamplers = nil
+	_trackedMu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		// the last reports of the gin samplers are flushed with the loggers
+		for _, sampler := range samplers {
+			sampler.stop()
+		}
+		for _, tracked := range loggers {
+			err = multierr.Append(err, tracked.close())
+		}
+		// rotating files shared with loggers built outside of the package
+		done <- multierr.Append(err, closeFileSinks())
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stderr and stdout can't be synced on most terminals
+func isIgnorableSyncError(err error) bool {
+	return errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOTTY) || errors.Is(err, syscall.EBADF)
+}
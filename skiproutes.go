@@ -0,0 +1,101 @@
+package zlogger
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+/* DOCS -
+route skipping of the gin loggers, every entry of skipRoutes is
+  /health          exact path
+  /health/*        glob, see path.Match
+  ~^/api/v[0-9]+/  regex, prefixed with ~
+  OPTIONS *        any of the above, only for one method
+*/
+
+type skipRoutePattern struct {
+	method string
+	glob   string
+	regex  *regexp.Regexp
+}
+
+func (p skipRoutePattern) match(method, requestPath string) bool {
+	if p.method != "" && p.method != method {
+		return false
+	}
+	if p.regex != nil {
+		return p.regex.MatchString(requestPath)
+	}
+	matched, _ := path.Match(p.glob, requestPath)
+	return matched
+}
+
+type skipRouteMatcher struct {
+	// exact paths of any method, the common case
+	exact    map[string]struct{}
+	patterns []skipRoutePattern
+}
+
+func compileSkipRoutes(skipRoutes []string) (*skipRouteMatcher, error) {
+	matcher := &skipRouteMatcher{exact: make(map[string]struct{}, len(skipRoutes))}
+	for _, skipRoute := range skipRoutes {
+		var pattern skipRoutePattern
+		route := strings.TrimSpace(skipRoute)
+		if method, rest, ok := strings.Cut(route, " "); ok && isHTTPMethod(method) {
+			pattern.method = method
+			route = strings.TrimSpace(rest)
+		}
+
+		switch {
+		case route == "":
+			return nil, fmt.Errorf("skip route %q: empty path", skipRoute)
+		case strings.HasPrefix(route, "~"):
+			regex, err := regexp.Compile(route[1:])
+			if err != nil {
+				return nil, fmt.Errorf("skip route %q: %w", skipRoute, err)
+			}
+			pattern.regex = regex
+		case route == "*":
+			// any path
+			pattern.regex = regexp.MustCompile("")
+		case strings.ContainsAny(route, "*?["):
+			if _, err := path.Match(route, ""); err != nil {
+				return nil, fmt.Errorf("skip route %q: %w", skipRoute, err)
+			}
+			pattern.glob = route
+		case pattern.method == "":
+			matcher.exact[route] = struct{}{}
+			continue
+		default:
+			pattern.glob = route
+		}
+		matcher.patterns = append(matcher.patterns, pattern)
+	}
+	return matcher, nil
+}
+
+func (m *skipRouteMatcher) match(method, requestPath string) bool {
+	if _, ok := m.exact[requestPath]; ok {
+		return true
+	}
+	for _, pattern := range m.patterns {
+		if pattern.match(method, requestPath) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHTTPMethod(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, r := range token {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package zlogger
+
+import "go.uber.org/zap/zapcore"
+
+// StatusLevels maps the status code class of a request to the level of its gin entry.
+// Every field is used as is, unset ones are Info (the zero Level),
+// start from DefaultStatusLevels to change only some of them.
+type StatusLevels struct {
+	// Success is used for 1xx and 2xx
+	Success zapcore.Level
+	// Redirect is used for 3xx
+	Redirect zapcore.Level
+	// ClientError is used for 4xx
+	ClientError zapcore.Level
+	// ServerError is used for 5xx
+	ServerError zapcore.Level
+}
+
+// DefaultStatusLevels returns Info for 1xx-3xx, Warn for 4xx and Error for 5xx
+func DefaultStatusLevels() StatusLevels {
+	return StatusLevels{
+		Success:     zapcore.InfoLevel,
+		Redirect:    zapcore.InfoLevel,
+		ClientError: zapcore.WarnLevel,
+		ServerError: zapcore.ErrorLevel,
+	}
+}
+
+// Level returns the level of statusCode
+func (sl StatusLevels) Level(statusCode int) zapcore.Level {
+	switch {
+	case statusCode >= 500:
+		return sl.ServerError
+	case statusCode >= 400:
+		return sl.ClientError
+	case statusCode >= 300:
+		return sl.Redirect
+	default:
+		return sl.Success
+	}
+}
@@ -0,0 +1,96 @@
+package zlogger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAccessLogFormats(t *testing.T) {
+	serveLevel := func(t *testing.T, level zapcore.Level, overrides map[string]zapcore.Level, options ...zlogger.GinOption) []string {
+		loggerConfig, filename := newFileLoggerConfig(t, "accesslog", level, zlogger.WithLevelOverrides(overrides))
+
+		ginEng := gin.New()
+		ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil, options...))
+		ginEng.GET("/docs/:name", func(c *gin.Context) {
+			c.String(http.StatusOK, "hello")
+		})
+		req := httptest.NewRequest(http.MethodGet, "/docs/a?lang=en", nil)
+		req.SetBasicAuth("frank", "secret")
+		req.Header.Set("Referer", "http://example.com/")
+		req.Header.Set("User-Agent", "Mozilla/5.0 (X11)")
+		ginEng.ServeHTTP(httptest.NewRecorder(), req)
+
+		content, err := os.ReadFile(filename)
+		assert.Equal(t, err, nil)
+		var lines []string
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			// lib entries are json
+			if !strings.HasPrefix(line, "{") {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+	serve := func(t *testing.T, options ...zlogger.GinOption) []string {
+		return serveLevel(t, zapcore.InfoLevel, nil, options...)
+	}
+	clfTime := `\[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\]`
+
+	t.Run("Test common format", func(t *testing.T) {
+		lines := serve(t, zlogger.WithAccessLogFormat(zlogger.COMMON_FORMAT))
+		assert.Equal(t, len(lines), 1)
+		assert.MatchRegex(t, lines[0], `^192\.0\.2\.1 - frank `+clfTime+` "GET /docs/a\?lang=en HTTP/1\.1" 200 5$`)
+	})
+
+	t.Run("Test combined format", func(t *testing.T) {
+		lines := serve(t, zlogger.WithAccessLogFormat(zlogger.COMBINED_FORMAT))
+		assert.Equal(t, len(lines), 1)
+		assert.MatchRegex(t, lines[0], `^192\.0\.2\.1 - frank `+clfTime+` "GET /docs/a\?lang=en HTTP/1\.1" 200 5 "http://example\.com/" "Mozilla/5\.0 \(X11\)"$`)
+	})
+
+	t.Run("Test w3c format", func(t *testing.T) {
+		lines := serve(t,
+			zlogger.WithAccessLogFormat(zlogger.W3C_FORMAT),
+			zlogger.WithW3CFields("date", "c-ip", "cs-method", "cs-uri-stem", "cs-uri-query", "sc-status", "cs(User-Agent)", "cs(X-Missing)", "time-taken"),
+		)
+		assert.Equal(t, len(lines), 3)
+		assert.Equal(t, lines[0], "#Version: 1.0")
+		assert.Equal(t, lines[1], "#Fields: date c-ip cs-method cs-uri-stem cs-uri-query sc-status cs(User-Agent) cs(X-Missing) time-taken")
+		date := time.Now().UTC().Format("2006-01-02")
+		assert.MatchRegex(t, lines[2], `^`+date+` 192\.0\.2\.1 GET /docs/a lang=en 200 Mozilla/5\.0\+\(X11\) - \d+\.\d{3}$`)
+	})
+
+	t.Run("Test w3c directives are written at any level", func(t *testing.T) {
+		lines := serveLevel(t, zapcore.WarnLevel, nil, zlogger.WithAccessLogFormat(zlogger.W3C_FORMAT))
+		assert.Equal(t, len(lines), 2)
+		assert.Equal(t, lines[0], "#Version: 1.0")
+	})
+
+	t.Run("Test gin level override applies to plain lines", func(t *testing.T) {
+		overrides := map[string]zapcore.Level{"accesslog.gin": zapcore.InfoLevel}
+		lines := serveLevel(t, zapcore.WarnLevel, overrides, zlogger.WithAccessLogFormat(zlogger.COMMON_FORMAT))
+		assert.Equal(t, len(lines), 1)
+	})
+
+	t.Run("Test formatters with gin.LoggerWithConfig", func(t *testing.T) {
+		params := gin.LogFormatterParams{
+			TimeStamp:  time.Date(2000, 10, 10, 13, 55, 36, 0, time.FixedZone("", -7*3600)),
+			StatusCode: 200,
+			ClientIP:   "127.0.0.1",
+			Method:     "GET",
+			Path:       "/apache_pb.gif",
+			BodySize:   2326,
+		}
+		assert.Equal(t, zlogger.CommonLogFormatter(params), "127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] \"GET /apache_pb.gif HTTP/1.1\" 200 2326\n")
+		assert.Equal(t, zlogger.W3CLogFormatter("c-ip", "sc-status")(params), "127.0.0.1 200\n")
+	})
+}
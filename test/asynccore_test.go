@@ -0,0 +1,75 @@
+package zlogger_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newAsyncTestLogger(t *testing.T, asyncConfig zlogger.AsyncConfig) (zlogger.AppLogger, func() []string) {
+	loggerConfig, filename := newFileLoggerConfig(t, "async", zapcore.InfoLevel)
+	loggerConfig.SetAsync(asyncConfig)
+	appLogger := zlogger.MustNewAppLogger(loggerConfig)
+
+	return appLogger, func() []string {
+		content, _ := os.ReadFile(filename)
+		var lines []string
+		for _, line := range strings.Split(string(content), "\n") {
+			if strings.Contains(line, "async entry") {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+}
+
+func TestAsyncCore(t *testing.T) {
+	t.Run("Test sync drains the queue", func(t *testing.T) {
+		appLogger, lines := newAsyncTestLogger(t, zlogger.AsyncConfig{FlushInterval: time.Hour, BatchSize: 1000})
+		for i := 0; i < 10; i++ {
+			appLogger.Info("async entry")
+		}
+		assert.Equal(t, len(lines()), 0)
+
+		appLogger.Sync()
+		assert.Equal(t, len(lines()), 10)
+	})
+
+	t.Run("Test entries are encoded when logged", func(t *testing.T) {
+		appLogger, lines := newAsyncTestLogger(t, zlogger.AsyncConfig{FlushInterval: time.Hour, BatchSize: 1000})
+		order := map[string]string{"status": "created"}
+		appLogger.Info("async entry", zap.Any("order", order))
+		order["status"] = "paid"
+
+		appLogger.Sync()
+		assert.Equal(t, len(lines()), 1)
+		assert.Equal(t, strings.Contains(lines()[0], `"status":"created"`), true)
+	})
+
+	t.Run("Test drop oldest counts dropped entries", func(t *testing.T) {
+		dropped := zlogger.AsyncDroppedEntries()
+		appLogger, lines := newAsyncTestLogger(t, zlogger.AsyncConfig{BufferSize: 8, DropPolicy: zlogger.DROP_OLDEST})
+		for i := 0; i < 1000; i++ {
+			appLogger.Info("async entry")
+		}
+		appLogger.Sync()
+		assert.Equal(t, uint64(len(lines()))+zlogger.AsyncDroppedEntries()-dropped, uint64(1000))
+	})
+
+	t.Run("Test block keeps every entry", func(t *testing.T) {
+		dropped := zlogger.AsyncDroppedEntries()
+		appLogger, lines := newAsyncTestLogger(t, zlogger.AsyncConfig{BufferSize: 8, DropPolicy: zlogger.BLOCK})
+		for i := 0; i < 1000; i++ {
+			appLogger.Info("async entry")
+		}
+		appLogger.Sync()
+		assert.Equal(t, len(lines()), 1000)
+		assert.Equal(t, zlogger.AsyncDroppedEntries(), dropped)
+	})
+}
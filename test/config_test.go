@@ -5,6 +5,7 @@ import (
 
 	"github.com/Zbyteio/zlogger-lib"
 	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -48,4 +49,54 @@ func TestConfigLogger(t *testing.T)  {
     assert.Equal(t, encodingType, "json")
     assert.Equal(t, logLevel, zapConfig.Level.Level())
   })
-}
\ No newline at end of file
+}
+type serviceConfig struct {
+  Logger zlogger.LoggerConfig
+}
+
+func TestConfigOptions(t *testing.T) {
+  t.Run("Test options on top of preset", func(t *testing.T) {
+    svcConfig := serviceConfig{
+      Logger: zlogger.NewLoggerConfig("applogger", zlogger.JSON_LOGGER, zapcore.InfoLevel,
+        zlogger.WithOutputPaths("stdout"),
+        zlogger.WithInitialFields(map[string]interface{}{"service": "svc"}),
+        zlogger.WithSampling(100, 10),
+        zlogger.WithCaller(false),
+        zlogger.WithLevelOverrides(map[string]zapcore.Level{"applogger.db": zapcore.DebugLevel}),
+      ),
+    }
+
+    zapConfig := svcConfig.Logger.GetZapConfig()
+    assert.Equal(t, zapConfig.Encoding, "json")
+    assert.Equal(t, zapConfig.OutputPaths, []string{"stdout"})
+    assert.Equal(t, zapConfig.InitialFields["service"], "svc")
+    assert.Equal(t, zapConfig.Sampling.Thereafter, 10)
+    assert.Equal(t, zapConfig.DisableCaller, true)
+
+    level, _ := svcConfig.Logger.GetLevelOverrides().Match("applogger.db")
+    assert.Equal(t, level, zapcore.DebugLevel)
+  })
+
+  t.Run("Test registered encoding", func(t *testing.T) {
+    // registered once per process, -count runs the test again
+    zap.RegisterEncoder("registered-json", func(encoderConfig zapcore.EncoderConfig) (zapcore.Encoder, error) {
+      return zapcore.NewJSONEncoder(encoderConfig), nil
+    })
+    loggerConfig, filename := newFileLoggerConfig(t, "registered", zapcore.InfoLevel,
+      zlogger.WithEncoding("registered-json"))
+    appLogger, err := zlogger.NewAppLogger(loggerConfig)
+    assert.Equal(t, err, nil)
+    appLogger.Info("registered encoder")
+
+    // the first entry is the lib logger announcing the app logger
+    entries := readJSONEntries(t, filename)
+    assert.Equal(t, entries[len(entries)-1]["message"], "registered encoder")
+  })
+
+  t.Run("Test bad encoding", func(t *testing.T) {
+    loggerConfig := zlogger.NewLoggerConfig("applogger", zlogger.JSON_LOGGER, zapcore.InfoLevel,
+      zlogger.WithEncoding("xml"))
+    _, err := zlogger.NewAppLogger(loggerConfig)
+    assert.NotEqual(t, err, nil)
+  })
+}
@@ -0,0 +1,72 @@
+package zlogger_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFileSink(t *testing.T) {
+	t.Run("Test sink url", func(t *testing.T) {
+		fileSinkConfig := zlogger.FileSinkConfig{
+			Filename:   "/var/log/service.log",
+			MaxSizeMB:  10,
+			MaxBackups: 3,
+			Compress:   true,
+		}
+		assert.Equal(t, fileSinkConfig.URL(), "rotate:///var/log/service.log?compress=true&maxBackups=3&maxSize=10")
+	})
+
+	t.Run("Test app and gin loggers share the file", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "logs", "service.log")
+
+		loggerConfig := zlogger.NewLoggerConfig("filesink", zlogger.JSON_LOGGER, zapcore.InfoLevel)
+		loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: filename, MaxSizeMB: 1})
+		assert.Equal(t, zlogger.SetupLoggerFromConfig(loggerConfig, nil, nil), nil)
+
+		zlogger.GetAppLogger().Info("written to file")
+		content, err := os.ReadFile(filename)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, strings.Contains(string(content), "written to file"), true)
+		assert.Equal(t, strings.Contains(string(content), "[JSON-GIN-LOGGER]"), true)
+	})
+
+	t.Run("Test other rotation settings are rejected", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "settings.log")
+
+		loggerConfig := zlogger.NewLoggerConfig("filesink", zlogger.JSON_LOGGER, zapcore.InfoLevel)
+		loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: filename, MaxSizeMB: 1})
+		_, err := zlogger.NewAppLogger(loggerConfig)
+		assert.Equal(t, err, nil)
+
+		loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: filename, MaxSizeMB: 1})
+		_, err = zlogger.NewAppLogger(loggerConfig)
+		assert.Equal(t, err, nil)
+
+		loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: filename, MaxSizeMB: 2})
+		_, err = zlogger.NewAppLogger(loggerConfig)
+		assert.NotEqual(t, err, nil)
+	})
+
+	t.Run("Test reopen after move", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "reopen.log")
+
+		loggerConfig := zlogger.NewLoggerConfig("filesink", zlogger.JSON_LOGGER, zapcore.InfoLevel)
+		loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: filename})
+		appLogger := zlogger.MustNewAppLogger(loggerConfig)
+
+		appLogger.Info("before move")
+		assert.Equal(t, os.Rename(filename, filename+".1"), nil)
+		assert.Equal(t, zlogger.ReopenFileSinks(), nil)
+		appLogger.Info("after move")
+
+		content, _ := os.ReadFile(filename)
+		assert.Equal(t, strings.Contains(string(content), "before move"), false)
+		assert.Equal(t, strings.Contains(string(content), "after move"), true)
+	})
+}
@@ -0,0 +1,82 @@
+package zlogger_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGinBodyCapture(t *testing.T) {
+	loggerConfig, filename := newFileLoggerConfig(t, "ginbody", zapcore.InfoLevel)
+
+	ginEng := gin.New()
+	ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil,
+		zlogger.WithBodyCapture(zlogger.BodyCapture{
+			MaxBytes:     80,
+			ContentTypes: []string{"application/json", "application/x-www-form-urlencoded"},
+			Routes:       []string{"/login", "/echo"},
+			RedactPaths:  []string{"user.card.number"},
+		}),
+	))
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, c.ContentType(), body)
+	}
+	ginEng.POST("/login", func(c *gin.Context) {
+		var login map[string]interface{}
+		assert.Equal(t, c.BindJSON(&login), nil)
+		c.JSON(http.StatusOK, gin.H{"access_token": "abc", "user": login["user"]})
+	})
+	ginEng.POST("/echo", echo)
+	ginEng.POST("/uncaptured", echo)
+
+	post := func(path, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		ginEng.ServeHTTP(w, req)
+		return w
+	}
+	post("/login", "application/json", `{"user":{"name":"ann","card":{"number":"4111"}},"Password":"hunter2"}`)
+	longBody := `{"note":"` + strings.Repeat("x", 60) + `","token":"secret-token"}`
+	w := post("/echo", "application/json", longBody)
+	post("/echo", "application/x-www-form-urlencoded", "user=ann&password=hunter2")
+	post("/echo", "text/plain", "plain text")
+	post("/uncaptured", "application/json", `{"a":1}`)
+
+	entries := readGinEntries(t, filename)
+	assert.Equal(t, len(entries), 5)
+
+	t.Run("Test json bodies are redacted", func(t *testing.T) {
+		assert.Equal(t, entries[0]["requestBody"], map[string]interface{}{
+			"user":     map[string]interface{}{"name": "ann", "card": map[string]interface{}{"number": zlogger.REDACTED}},
+			"Password": zlogger.REDACTED,
+		})
+		responseBody := entries[0]["responseBody"].(map[string]interface{})
+		assert.Equal(t, responseBody["access_token"], zlogger.REDACTED)
+	})
+
+	t.Run("Test bodies are truncated", func(t *testing.T) {
+		assert.Equal(t, w.Body.String(), longBody)
+		// the partial token is redacted as well
+		assert.Equal(t, entries[1]["requestBody"], longBody[:69]+`","token":"[REDACTED]"`)
+		assert.Equal(t, entries[1]["requestBodyTruncated"], true)
+		assert.Equal(t, entries[1]["responseBodyTruncated"], true)
+	})
+
+	t.Run("Test form bodies are redacted", func(t *testing.T) {
+		assert.Equal(t, entries[2]["requestBody"], "password=%5BREDACTED%5D&user=ann")
+	})
+
+	t.Run("Test content types and routes are filtered", func(t *testing.T) {
+		assert.Equal(t, entries[3]["requestBody"], nil)
+		assert.Equal(t, entries[4]["requestBody"], nil)
+	})
+}
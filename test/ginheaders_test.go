@@ -0,0 +1,63 @@
+package zlogger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGinHeaderLogging(t *testing.T) {
+	loggerConfig, filename := newFileLoggerConfig(t, "ginheaders", zapcore.InfoLevel)
+
+	ginEng := gin.New()
+	ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil,
+		zlogger.WithHeaderLogging(zlogger.HeaderLogging{
+			Request:  true,
+			Response: true,
+			Allow:    []string{"user-agent", "X-FORWARDED-FOR", "x-tenant-id", "authorization", "cookie", "set-cookie", "x-api-key", "x-internal"},
+			Deny:     []string{"X-Internal"},
+			Mask:     []string{"x-api-key"},
+		}),
+	))
+	ginEng.GET("/headers", func(c *gin.Context) {
+		c.Header("X-Internal", "hidden")
+		c.Header("Set-Cookie", "session=abc123; Path=/; HttpOnly")
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/headers", nil)
+	req.Header.Set("User-Agent", "zlogger-test")
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	req.Header.Set("X-Tenant-Id", "tenant-1")
+	req.Header.Set("Authorization", "Bearer eyJhbGciOi")
+	req.Header.Set("Cookie", "session=abc123; theme=dark")
+	req.Header.Set("X-Api-Key", "key-1")
+	req.Header.Set("X-Internal", "hidden")
+	req.Header.Set("Accept", "*/*")
+	ginEng.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := readGinEntries(t, filename)
+	assert.Equal(t, len(entries), 1)
+
+	t.Run("Test allowed request headers are logged and masked", func(t *testing.T) {
+		assert.Equal(t, entries[0]["requestHeaders"], map[string]interface{}{
+			"User-Agent":      "zlogger-test",
+			"X-Forwarded-For": "10.0.0.1",
+			"X-Tenant-Id":     "tenant-1",
+			"Authorization":   "Bearer [REDACTED]",
+			"Cookie":          "session=[REDACTED]; theme=[REDACTED]",
+			"X-Api-Key":       "[REDACTED]",
+		})
+	})
+
+	t.Run("Test response headers are logged and masked", func(t *testing.T) {
+		assert.Equal(t, entries[0]["responseHeaders"], map[string]interface{}{
+			"Set-Cookie": "session=[REDACTED]; Path=/; HttpOnly",
+		})
+	})
+}
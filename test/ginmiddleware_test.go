@@ -0,0 +1,319 @@
+package zlogger_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGinMiddleware(t *testing.T) {
+	loggerConfig, filename := newFileLoggerConfig(t, "ginmiddleware", zapcore.InfoLevel)
+
+	ginEng := gin.New()
+	ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil))
+	ginEng.POST("/echo", func(c *gin.Context) {
+		body, _ := c.GetRawData()
+		c.Data(http.StatusCreated, "text/plain", body)
+	})
+	ginEng.GET("/fail", func(c *gin.Context) {
+		c.Error(errors.New("first failure"))
+		c.Error(errors.New("second failure"))
+		c.Status(http.StatusInternalServerError)
+	})
+
+	// gin.New writes its debug warning to gin's output
+	var ginOutput bytes.Buffer
+	defaultWriter := gin.DefaultWriter
+	gin.DefaultWriter = &ginOutput
+	defer func() { gin.DefaultWriter = defaultWriter }()
+
+	ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/echo?q=1", strings.NewReader("hello")))
+	ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	entries := readGinEntries(t, filename)
+	assert.Equal(t, len(entries), 2)
+
+	t.Run("Test sizes are logged", func(t *testing.T) {
+		assert.Equal(t, entries[0]["requestUrl"], "/echo?q=1")
+		assert.Equal(t, entries[0]["route"], "/echo")
+		assert.Equal(t, entries[0]["statusCode"], float64(http.StatusCreated))
+		assert.Equal(t, entries[0]["requestSize"], float64(5))
+		assert.Equal(t, entries[0]["responseSize"], float64(5))
+		assert.Equal(t, entries[0]["errors"], nil)
+	})
+
+	t.Run("Test handler errors are logged", func(t *testing.T) {
+		assert.Equal(t, entries[1]["errors"], []interface{}{"first failure", "second failure"})
+		assert.Equal(t, strings.Contains(entries[1]["error"].(string), "first failure"), true)
+		assert.Equal(t, entries[1]["responseSize"], float64(0))
+	})
+
+	t.Run("Test nothing is written to gin output", func(t *testing.T) {
+		assert.Equal(t, ginOutput.Len(), 0)
+	})
+}
+
+func TestGinRoute(t *testing.T) {
+	loggerConfig, filename := newFileLoggerConfig(t, "ginroute", zapcore.InfoLevel)
+
+	ginEng := gin.New()
+	ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil,
+		zlogger.WithRouteAsMessage(true),
+		zlogger.WithUnmatchedRoute("NOT_FOUND"),
+	))
+	ginEng.GET("/users/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/123", nil))
+	ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/456?full=1", nil))
+	ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	entries := readGinEntries(t, filename)
+	assert.Equal(t, len(entries), 3)
+
+	t.Run("Test route template is the message", func(t *testing.T) {
+		assert.Equal(t, entries[0]["requestUrl"], "/users/:id")
+		assert.Equal(t, entries[0]["route"], "/users/:id")
+		assert.Equal(t, entries[0]["path"], "/users/123")
+		assert.Equal(t, entries[1]["requestUrl"], "/users/:id")
+		assert.Equal(t, entries[1]["path"], "/users/456?full=1")
+	})
+
+	t.Run("Test unmatched route label", func(t *testing.T) {
+		assert.Equal(t, entries[2]["statusCode"], float64(http.StatusNotFound))
+		assert.Equal(t, entries[2]["route"], "NOT_FOUND")
+		assert.Equal(t, entries[2]["path"], "/missing")
+	})
+}
+
+func TestGinStatusLevels(t *testing.T) {
+	loggerConfig, filename := newFileLoggerConfig(t, "ginlevels", zapcore.DebugLevel)
+
+	healthLevels := zlogger.DefaultStatusLevels()
+	healthLevels.Success = zapcore.DebugLevel
+	ginEng := gin.New()
+	ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil,
+		zlogger.WithRouteStatusLevels("/health", healthLevels),
+	))
+	ginEng.GET("/status/:code", func(c *gin.Context) {
+		code, _ := strconv.Atoi(c.Param("code"))
+		c.Status(code)
+	})
+	ginEng.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, path := range []string{"/status/200", "/status/302", "/status/404", "/status/503", "/health"} {
+		ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	t.Run("Test levels follow the status code", func(t *testing.T) {
+		var levels []interface{}
+		for _, entry := range readGinEntries(t, filename) {
+			levels = append(levels, entry["logLevel"])
+		}
+		assert.Equal(t, levels, []interface{}{"INFO", "INFO", "WARN", "ERROR", "DEBUG"})
+	})
+
+	t.Run("Test custom levels", func(t *testing.T) {
+		statusLevels := zlogger.StatusLevels{
+			Success:     zapcore.DebugLevel,
+			Redirect:    zapcore.DebugLevel,
+			ClientError: zapcore.InfoLevel,
+			ServerError: zapcore.ErrorLevel,
+		}
+		assert.Equal(t, statusLevels.Level(204), zapcore.DebugLevel)
+		assert.Equal(t, statusLevels.Level(429), zapcore.InfoLevel)
+		assert.Equal(t, statusLevels.Level(500), zapcore.ErrorLevel)
+	})
+}
+
+func TestGinSampling(t *testing.T) {
+	loggerConfig, filename := newFileLoggerConfig(t, "ginsampling", zapcore.InfoLevel)
+
+	ginEng := gin.New()
+	ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil,
+		zlogger.WithRequestSampling(zlogger.RequestSampling{
+			First:          1,
+			Every:          3,
+			ReportInterval: 50 * time.Millisecond,
+		}),
+		zlogger.WithSlowThreshold(20*time.Millisecond),
+	))
+	ginEng.GET("/hits", func(c *gin.Context) {
+		if c.Query("slow") != "" {
+			time.Sleep(25 * time.Millisecond)
+		}
+		if c.Query("fail") != "" {
+			c.Error(errors.New("failed"))
+		}
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(path string) {
+		ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	for i := 0; i < 6; i++ {
+		serve("/hits")
+	}
+	serve("/hits?slow=1")
+	serve("/hits?fail=1")
+	serve("/missing")
+	serve("/missing")
+
+	t.Run("Test successful requests are sampled", func(t *testing.T) {
+		var paths []interface{}
+		for _, entry := range readGinEntries(t, filename) {
+			paths = append(paths, entry["requestUrl"])
+			if entry["requestUrl"] == "/hits?slow=1" {
+				assert.Equal(t, entry["slow"], true)
+				assert.Equal(t, entry["logLevel"], "WARN")
+			}
+		}
+		assert.Equal(t, paths, []interface{}{"/hits", "/hits", "/hits?slow=1", "/hits?fail=1", "/missing", "/missing"})
+	})
+
+	t.Run("Test suppressed entries are reported without traffic", func(t *testing.T) {
+		// reports may be split by the ticker, they add up to the suppressed requests
+		var suppressed float64
+		deadline := time.Now().Add(2 * time.Second)
+		for suppressed < 4 && time.Now().Before(deadline) {
+			time.Sleep(20 * time.Millisecond)
+			suppressed = 0
+			for _, entry := range readJSONEntries(t, filename) {
+				if entry["suppressed"] != nil {
+					suppressed += entry["suppressed"].(float64)
+					assert.Equal(t, entry["suppressedByRoute"].(map[string]interface{})["/hits"], entry["suppressed"])
+				}
+			}
+		}
+		assert.Equal(t, suppressed, float64(4))
+	})
+}
+
+func TestGinSkipRoutes(t *testing.T) {
+	loggerConfig, filename := newFileLoggerConfig(t, "ginskip", zapcore.InfoLevel)
+
+	ginEng := gin.New()
+	ginEng.Use(zlogger.MustNewGinLogger(loggerConfig,
+		[]string{"/metrics", "/health/*", "~^/static/.+\\.css$", "OPTIONS *", "DELETE /orders/1"},
+		zlogger.WithSkipper(func(c *gin.Context) bool {
+			return c.GetHeader("X-Synthetic") != "" && c.Writer.Status() < 400
+		}),
+	))
+	ginEng.NoRoute(func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serve := func(method, path string, headers ...string) {
+		req := httptest.NewRequest(method, path, nil)
+		if len(headers) == 2 {
+			req.Header.Set(headers[0], headers[1])
+		}
+		ginEng.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	serve(http.MethodGet, "/metrics")
+	serve(http.MethodGet, "/health/live")
+	serve(http.MethodGet, "/static/site.css")
+	serve(http.MethodOptions, "/orders")
+	serve(http.MethodDelete, "/orders/1")
+	serve(http.MethodGet, "/probe", "X-Synthetic", "1")
+
+	serve(http.MethodGet, "/health")
+	serve(http.MethodGet, "/static/site.js")
+	serve(http.MethodGet, "/orders/1")
+
+	t.Run("Test patterns, methods and skippers", func(t *testing.T) {
+		var paths []interface{}
+		for _, entry := range readGinEntries(t, filename) {
+			paths = append(paths, entry["requestUrl"])
+		}
+		assert.Equal(t, paths, []interface{}{"/health", "/static/site.js", "/orders/1"})
+	})
+
+	t.Run("Test invalid pattern", func(t *testing.T) {
+		_, err := zlogger.NewGinLogger(loggerConfig, []string{"~^/api/(v1"})
+		var configErr *zlogger.ConfigError
+		assert.Equal(t, errors.As(err, &configErr), true)
+
+		settings := zlogger.Settings{SkipRoutes: []string{"/health/[a"}}
+		var settingsErr *zlogger.SettingsError
+		assert.Equal(t, errors.As(settings.Validate(), &settingsErr), true)
+		assert.Equal(t, settingsErr.Key, "skipRoutes")
+	})
+}
+
+func TestGinSlowRequests(t *testing.T) {
+	newEngine := func(loggerConfig zlogger.LoggerConfig, options ...zlogger.GinOption) *gin.Engine {
+		ginEng := gin.New()
+		ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil, options...))
+		handler := func(c *gin.Context) {
+			time.Sleep(30 * time.Millisecond)
+			c.Status(http.StatusOK)
+		}
+		ginEng.GET("/report", handler)
+		ginEng.GET("/bulk", handler)
+		ginEng.GET("/fast", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return ginEng
+	}
+	serve := func(ginEng *gin.Engine, paths ...string) {
+		for _, path := range paths {
+			ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		}
+	}
+
+	t.Run("Test slow requests are logged at warn", func(t *testing.T) {
+		loggerConfig, filename := newFileLoggerConfig(t, "ginslow", zapcore.InfoLevel)
+		serve(newEngine(loggerConfig,
+			zlogger.WithSlowThreshold(20*time.Millisecond),
+			zlogger.WithRouteSlowThreshold("/bulk", time.Second),
+		), "/report", "/bulk", "/fast")
+
+		entries := readGinEntries(t, filename)
+		assert.Equal(t, len(entries), 3)
+		assert.Equal(t, entries[0]["logLevel"], "WARN")
+		assert.Equal(t, entries[0]["slow"], true)
+		assert.Equal(t, entries[1]["logLevel"], "INFO")
+		assert.Equal(t, entries[1]["slow"], nil)
+		assert.Equal(t, entries[2]["slow"], nil)
+	})
+
+	t.Run("Test slow requests and color bands in debug mode", func(t *testing.T) {
+		filename := filepath.Join(t.TempDir(), "slow-debug.log")
+		loggerConfig := zlogger.NewLoggerConfig("ginslow", zlogger.DEBUG_LOGGER, zapcore.InfoLevel)
+		loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: filename})
+		serve(newEngine(loggerConfig,
+			zlogger.WithRouteSlowThreshold("/report", 20*time.Millisecond),
+			zlogger.WithLatencyColorBands(5*time.Millisecond, time.Second),
+		), "/report", "/bulk")
+
+		content, err := os.ReadFile(filename)
+		assert.Equal(t, err, nil)
+		var lines []string
+		for _, line := range strings.Split(string(content), "\n") {
+			if strings.Contains(line, "ginslow.gin") && strings.Contains(line, "200") {
+				lines = append(lines, line)
+			}
+		}
+		assert.Equal(t, len(lines), 2)
+		assert.MatchRegex(t, lines[0], `WARN.*/report.*\x1b\[31;1m[0-9.]+ms\x1b\[0m \x1b\[41;1m SLOW `)
+		// yellow band, not slow
+		assert.MatchRegex(t, lines[1], `INFO.*/bulk.*\x1b\[33;1m[0-9.]+ms\x1b\[0m`)
+		assert.Equal(t, strings.Contains(lines[1], "SLOW"), false)
+	})
+}
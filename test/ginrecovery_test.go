@@ -0,0 +1,80 @@
+package zlogger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGinRecovery(t *testing.T) {
+	loggerConfig, filename := newFileLoggerConfig(t, "ginrecovery", zapcore.InfoLevel)
+
+	loggerSet, err := zlogger.SetupNamedLoggerFromConfig("ginrecovery", loggerConfig, nil, nil)
+	assert.Equal(t, err, nil)
+
+	ginEng := gin.New()
+	ginEng.Use(
+		zlogger.RequestID(zlogger.RequestIDConfig{}),
+		loggerSet.GetGinLogger(zlogger.WithUnmatchedRoute("no-route")),
+		loggerSet.GetGinRecovery(func(c *gin.Context, recovered interface{}) {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "internal error"})
+		}, zlogger.WithUnmatchedRoute("no-route")),
+	)
+	ginEng.GET("/orders/:id", func(c *gin.Context) {
+		var orders map[string]string
+		orders[c.Param("id")] = "created"
+	})
+	ginEng.NoRoute(func(c *gin.Context) {
+		panic("no route")
+	})
+
+	w := httptest.NewRecorder()
+	ginEng.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/7", nil))
+	ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	t.Run("Test configured response", func(t *testing.T) {
+		assert.Equal(t, w.Code, http.StatusServiceUnavailable)
+		assert.Equal(t, w.Body.String(), `{"error":"internal error"}`)
+	})
+
+	t.Run("Test panic is logged", func(t *testing.T) {
+		var panics []map[string]interface{}
+		for _, entry := range readJSONEntries(t, filename) {
+			if entry["panic"] != nil {
+				panics = append(panics, entry)
+			}
+		}
+		assert.Equal(t, len(panics), 2)
+		assert.Equal(t, panics[0]["logLevel"], "ERROR")
+		assert.Equal(t, panics[0]["requestUrl"], "/orders/7")
+		assert.Equal(t, panics[0]["route"], "/orders/:id")
+		assert.Equal(t, panics[0]["requestMethod"], "GET")
+		assert.Equal(t, panics[0]["requestId"], w.Header().Get(zlogger.REQUEST_ID_HEADER))
+		assert.Equal(t, strings.Contains(panics[0]["panic"].(string), "assignment to entry in nil map"), true)
+		assert.Equal(t, strings.Contains(panics[0]["stacktrace"].(string), "ginrecovery_test.go"), true)
+		assert.Equal(t, panics[1]["route"], "no-route")
+	})
+
+	t.Run("Test recovery shares the gin level", func(t *testing.T) {
+		ginLevel, _ := loggerSet.GetAtomicLevel(zlogger.GIN_LOGGER)
+		ginLevel.SetLevel(zapcore.FatalLevel)
+		defer ginLevel.SetLevel(zapcore.InfoLevel)
+
+		ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/8", nil))
+		for _, entry := range readJSONEntries(t, filename) {
+			assert.NotEqual(t, entry["requestUrl"], "/orders/8")
+		}
+	})
+
+	t.Run("Test access entry has the error status", func(t *testing.T) {
+		entries := readGinEntries(t, filename)
+		assert.Equal(t, entries[0]["statusCode"], float64(http.StatusServiceUnavailable))
+		assert.Equal(t, entries[0]["stacktrace"], nil)
+	})
+}
@@ -0,0 +1,69 @@
+package zlogger_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"go.uber.org/zap/zapcore"
+)
+
+// newFileLoggerConfig returns a json logger config writing to a file
+// in a temp dir of t, and the name of that file
+func newFileLoggerConfig(t *testing.T, loggerName string, level zapcore.Level, options ...zlogger.LoggerOption) (zlogger.LoggerConfig, string) {
+	filename := filepath.Join(t.TempDir(), loggerName+".log")
+	loggerConfig := zlogger.NewLoggerConfig(loggerName, zlogger.JSON_LOGGER, level, options...)
+	loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: filename})
+	return loggerConfig, filename
+}
+
+// skips tests counting the open files of the process
+func skipWithoutProcFD(t *testing.T) {
+	if _, err := os.Stat("/proc/self/fd"); err != nil {
+		t.Skip("open files are counted with /proc/self/fd")
+	}
+}
+
+// number of files open by the process whose path starts with prefix
+func openFiles(prefix string) int {
+	entries, _ := os.ReadDir("/proc/self/fd")
+	count := 0
+	for _, entry := range entries {
+		target, _ := os.Readlink(filepath.Join("/proc/self/fd", entry.Name()))
+		if strings.HasPrefix(target, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// json entries written to a rotate:// file sink
+func readJSONEntries(t *testing.T, filename string) []map[string]interface{} {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// the access entries, without the lib entries and the gin route list
+func readGinEntries(t *testing.T, filename string) []map[string]interface{} {
+	var entries []map[string]interface{}
+	for _, entry := range readJSONEntries(t, filename) {
+		if entry["statusCode"] != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,58 @@
+package zlogger_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevelHandler(t *testing.T) {
+	zlogger.MustSetupLoggerWithConfig("levels", zlogger.JSON_LOGGER, nil, nil)
+
+	ginEng := gin.New()
+	zlogger.MountLevelHandler(ginEng, "/log/level")
+
+	serve := func(method string, target string, body string) (int, map[string]string) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		ginEng.ServeHTTP(w, r)
+		payload := map[string]string{}
+		json.Unmarshal(w.Body.Bytes(), &payload)
+		return w.Code, payload
+	}
+
+	t.Run("Test get all levels", func(t *testing.T) {
+		code, payload := serve(http.MethodGet, "/log/level", "")
+		assert.Equal(t, code, http.StatusOK)
+		assert.Equal(t, payload[zlogger.APP_LOGGER], "info")
+		assert.Equal(t, payload[zlogger.GIN_LOGGER], "info")
+		assert.Equal(t, payload[zlogger.GORM_LOGGER], "info")
+	})
+
+	t.Run("Test change gin level only", func(t *testing.T) {
+		code, payload := serve(http.MethodPut, "/log/level?logger=gin", `{"level":"debug"}`)
+		assert.Equal(t, code, http.StatusOK)
+		assert.Equal(t, payload["level"], "debug")
+
+		ginLevel, _ := zlogger.GetAtomicLevel(zlogger.GIN_LOGGER)
+		appLevel, _ := zlogger.GetAtomicLevel(zlogger.APP_LOGGER)
+		assert.Equal(t, ginLevel.Level(), zapcore.DebugLevel)
+		assert.Equal(t, appLevel.Level(), zapcore.InfoLevel)
+	})
+
+	t.Run("Test unknown logger", func(t *testing.T) {
+		code, _ := serve(http.MethodGet, "/log/level?logger=unknown", "")
+		assert.Equal(t, code, http.StatusNotFound)
+
+		code, _ = serve(http.MethodPut, "/log/level", `{"level":"debug"}`)
+		assert.Equal(t, code, http.StatusBadRequest)
+	})
+}
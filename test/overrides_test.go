@@ -0,0 +1,104 @@
+package zlogger_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+// builds loggers while stderr points to a file and returns its content
+func captureStderr(t *testing.T, build func()) func() string {
+	path := filepath.Join(t.TempDir(), "stderr.log")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = file
+	build()
+	os.Stderr = stderr
+
+	return func() string {
+		content, _ := os.ReadFile(path)
+		return string(content)
+	}
+}
+
+func TestLevelOverrides(t *testing.T) {
+	t.Run("Test longest prefix match", func(t *testing.T) {
+		overrides := zlogger.NewLevelOverrides(map[string]zapcore.Level{
+			"payments":    zapcore.WarnLevel,
+			"payments.db": zapcore.DebugLevel,
+		})
+
+		level, ok := overrides.Match("payments.db.query")
+		assert.Equal(t, ok, true)
+		assert.Equal(t, level, zapcore.DebugLevel)
+
+		level, _ = overrides.Match("payments.api")
+		assert.Equal(t, level, zapcore.WarnLevel)
+
+		_, ok = overrides.Match("paymentsdb")
+		assert.Equal(t, ok, false)
+
+		overrides.Remove("payments.db")
+		level, _ = overrides.Match("payments.db.query")
+		assert.Equal(t, level, zapcore.WarnLevel)
+	})
+
+	t.Run("Test override core", func(t *testing.T) {
+		var loggerSet *zlogger.LoggerSet
+		output := captureStderr(t, func() {
+			loggerConfig := zlogger.NewLoggerConfig("payments", zlogger.JSON_LOGGER, zapcore.InfoLevel)
+			loggerConfig.GetLevelOverrides().Set("payments.db", zapcore.DebugLevel)
+			loggerSet, _ = zlogger.SetupNamedLoggerFromConfig("payments", loggerConfig, nil, nil)
+		})
+		appLogger := loggerSet.GetAppLogger()
+
+		appLogger.Named("db").Debug("db debug message")
+		appLogger.Named("api").Debug("api debug message")
+		appLogger.Named("api").Info("api info message")
+
+		assert.Equal(t, strings.Contains(output(), "db debug message"), true)
+		assert.Equal(t, strings.Contains(output(), "api debug message"), false)
+		assert.Equal(t, strings.Contains(output(), "api info message"), true)
+
+		// change at runtime via the level handler
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, "/?name=payments.api", strings.NewReader(`{"level":"debug"}`))
+		loggerSet.LevelHandler().ServeHTTP(w, r)
+		assert.Equal(t, w.Code, http.StatusOK)
+
+		appLogger.Named("api").Debug("api debug after put")
+		assert.Equal(t, strings.Contains(output(), "api debug after put"), true)
+
+		w = httptest.NewRecorder()
+		r = httptest.NewRequest(http.MethodDelete, "/?name=payments.api", nil)
+		loggerSet.LevelHandler().ServeHTTP(w, r)
+		assert.Equal(t, w.Code, http.StatusNoContent)
+
+		appLogger.Named("api").Debug("api debug after delete")
+		assert.Equal(t, strings.Contains(output(), "api debug after delete"), false)
+	})
+
+	t.Run("Test overrides are sampled", func(t *testing.T) {
+		loggerConfig, filename := newFileLoggerConfig(t, "sampled", zapcore.WarnLevel,
+			zlogger.WithSampling(2, 1000),
+			zlogger.WithLevelOverrides(map[string]zapcore.Level{"sampled": zapcore.InfoLevel}))
+		appLogger := zlogger.MustNewAppLogger(loggerConfig)
+		for i := 0; i < 100; i++ {
+			appLogger.Info("sampled entry")
+		}
+		appLogger.Sync()
+
+		content, _ := os.ReadFile(filename)
+		assert.Equal(t, strings.Count(string(content), "sampled entry"), 2)
+	})
+}
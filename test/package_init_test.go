@@ -1,9 +1,14 @@
 package zlogger_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Zbyteio/zlogger-lib"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 
@@ -11,7 +16,51 @@ func TestPackageInit(t *testing.T) {
   t.Run("Test for init function", func(t *testing.T) {
 
     zlogger.GetAppLogger().Info("Logged via fefault app logger")
-    zlogger.SetupLoggerWithConfig("zlogger", zlogger.DEBUG_LOGGER, nil, nil)
+    err := zlogger.SetupLoggerWithConfig("zlogger", zlogger.DEBUG_LOGGER, nil, nil)
+    assert.Equal(t, err, nil)
     zlogger.GetAppLogger().Info("Logged via new app logger")
   })
-}
\ No newline at end of file
+}
+func TestSetupErrors(t *testing.T) {
+  t.Run("Test bad output path", func(t *testing.T) {
+    loggerConfig := zlogger.NewLoggerConfig("broken", zlogger.JSON_LOGGER, zapcore.InfoLevel)
+    loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: "/dev/null/service.log"})
+    defaultLogger := zlogger.GetAppLogger()
+
+    err := zlogger.SetupLoggerFromConfig(loggerConfig, nil, nil)
+    var configErr *zlogger.ConfigError
+    assert.Equal(t, errors.As(err, &configErr), true)
+    assert.Equal(t, configErr.LoggerName, "broken")
+    assert.Equal(t, zlogger.GetAppLogger(), defaultLogger)
+
+    _, err = zlogger.NewGinLoggerConfig(loggerConfig, nil)
+    assert.NotEqual(t, err, nil)
+    assert.NotEqual(t, zlogger.SetupGormLogger(nil, loggerConfig), nil)
+  })
+
+  t.Run("Test bad skip route changes nothing", func(t *testing.T) {
+    defaultLogger := zlogger.GetAppLogger()
+    defaultLevel, _ := zlogger.GetAtomicLevel(zlogger.APP_LOGGER)
+    defaultGormLogger := gormlogger.Default
+    db := &gorm.DB{Config: &gorm.Config{Logger: defaultGormLogger}}
+
+    loggerConfig := zlogger.NewLoggerConfig("broken", zlogger.JSON_LOGGER, zapcore.ErrorLevel)
+    err := zlogger.SetupLoggerFromConfig(loggerConfig, db, []string{"~("})
+    var configErr *zlogger.ConfigError
+    assert.Equal(t, errors.As(err, &configErr), true)
+    assert.Equal(t, zlogger.GetAppLogger(), defaultLogger)
+    appLevel, _ := zlogger.GetAtomicLevel(zlogger.APP_LOGGER)
+    assert.Equal(t, appLevel, defaultLevel)
+    assert.Equal(t, gormlogger.Default, defaultGormLogger)
+    assert.Equal(t, db.Logger, defaultGormLogger)
+  })
+
+  t.Run("Test must variants panic", func(t *testing.T) {
+    loggerConfig := zlogger.NewLoggerConfig("broken", zlogger.JSON_LOGGER, zapcore.InfoLevel)
+    loggerConfig.SetFileSink(zlogger.FileSinkConfig{Filename: "/dev/null/service.log"})
+    defer func() {
+      assert.NotEqual(t, recover(), nil)
+    }()
+    zlogger.MustNewAppLogger(loggerConfig)
+  })
+}
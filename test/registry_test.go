@@ -0,0 +1,99 @@
+package zlogger_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLoggerRegistry(t *testing.T) {
+	t.Run("Test named logger sets", func(t *testing.T) {
+		loggerConfig := zlogger.NewLoggerConfig("billing", zlogger.JSON_LOGGER, zapcore.InfoLevel,
+			zlogger.WithOutputPaths("stdout"))
+		loggerSet, err := zlogger.SetupNamedLoggerFromConfig("billing", loggerConfig, nil, nil)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, loggerSet.GetName(), "billing")
+
+		found, ok := zlogger.LookupLoggerSet("billing")
+		assert.Equal(t, ok, true)
+		assert.Equal(t, found == loggerSet, true)
+		assert.Equal(t, zlogger.DefaultLoggerSet() == loggerSet, false)
+		assert.NotEqual(t, found.GetGinConfig().Formatter, nil)
+
+		_, ok = zlogger.LookupLoggerSet("missing")
+		assert.Equal(t, ok, false)
+
+		names := strings.Join(zlogger.LoggerSetNames(), ",")
+		assert.Equal(t, strings.Contains(names, "billing,"+zlogger.DEFAULT_LOGGER_SET), true)
+	})
+
+	t.Run("Test named set keeps the default levels", func(t *testing.T) {
+		defaultLevel, _ := zlogger.GetAtomicLevel(zlogger.APP_LOGGER)
+		loggerConfig := zlogger.NewLoggerConfig("audit", zlogger.JSON_LOGGER, zapcore.ErrorLevel,
+			zlogger.WithOutputPaths("stdout"))
+		loggerSet, err := zlogger.SetupNamedLoggerFromConfig("audit", loggerConfig, nil, nil)
+		assert.Equal(t, err, nil)
+
+		appLevel, _ := zlogger.GetAtomicLevel(zlogger.APP_LOGGER)
+		assert.Equal(t, appLevel.Level(), defaultLevel.Level())
+		auditLevel, ok := loggerSet.GetAtomicLevel(zlogger.APP_LOGGER)
+		assert.Equal(t, ok, true)
+		assert.Equal(t, auditLevel.Level(), zapcore.ErrorLevel)
+	})
+
+	t.Run("Test default set replaced while logging", func(t *testing.T) {
+		var wg sync.WaitGroup
+		stop := make(chan struct{})
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+						zlogger.GetAppLogger().Debug("logging during setup")
+						_ = zlogger.GetGinConfig()
+					}
+				}
+			}()
+		}
+
+		for i := 0; i < 5; i++ {
+			loggerConfig := zlogger.NewLoggerConfig("registry", zlogger.JSON_LOGGER, zapcore.WarnLevel,
+				zlogger.WithOutputPaths("stdout"))
+			assert.Equal(t, zlogger.SetupLoggerFromConfig(loggerConfig, nil, nil), nil)
+		}
+		close(stop)
+		wg.Wait()
+		defaultConfig := zlogger.DefaultLoggerSet().GetLoggerConfig()
+		assert.Equal(t, defaultConfig.GetLoggerName(), "registry")
+	})
+
+	t.Run("Test replaced set keeps its outputs until closed", func(t *testing.T) {
+		skipWithoutProcFD(t)
+		dir := t.TempDir()
+		firstLog := filepath.Join(dir, "first.log")
+		replaced, err := zlogger.SetupNamedLoggerFromConfig("replaced", zlogger.NewLoggerConfig("replaced",
+			zlogger.JSON_LOGGER, zapcore.InfoLevel, zlogger.WithOutputPaths(firstLog)), nil, nil)
+		assert.Equal(t, err, nil)
+		_, err = zlogger.SetupNamedLoggerFromConfig("replaced", zlogger.NewLoggerConfig("replaced",
+			zlogger.JSON_LOGGER, zapcore.InfoLevel, zlogger.WithOutputPaths(filepath.Join(dir, "second.log"))), nil, nil)
+		assert.Equal(t, err, nil)
+
+		replaced.GetAppLogger().Info("logged after replace")
+		assert.NotEqual(t, openFiles(firstLog), 0)
+
+		assert.Equal(t, replaced.Close(), nil)
+		assert.Equal(t, openFiles(firstLog), 0)
+		content, _ := os.ReadFile(firstLog)
+		assert.Equal(t, strings.Contains(string(content), "logged after replace"), true)
+	})
+}
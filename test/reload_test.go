@@ -0,0 +1,163 @@
+package zlogger_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+)
+
+func TestSettingsWatcher(t *testing.T) {
+	dir := t.TempDir()
+	firstLog := filepath.Join(dir, "first.log")
+	secondLog := filepath.Join(dir, "second.log")
+	settingsPath := filepath.Join(dir, "zlogger.yaml")
+	readLog := func(path string) string {
+		content, _ := os.ReadFile(path)
+		return string(content)
+	}
+	writeSettings := func(content string) {
+		if err := os.WriteFile(settingsPath, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeSettings(fmt.Sprintf("serviceName: reload\nloggerType: json\nlevel: info\noutputs: [\"rotate://%s\"]\n", firstLog))
+	settings, err := zlogger.LoadSettingsFromFile(settingsPath)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, zlogger.SetupLoggerFromSettings(settings, nil), nil)
+
+	watcher, err := zlogger.WatchSettingsFile(settingsPath, time.Hour)
+	assert.Equal(t, err, nil)
+	defer watcher.Close()
+
+	ginEng := gin.New()
+	ginEng.Use(gin.LoggerWithConfig(zlogger.GetGinConfig()))
+	ginEng.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	t.Run("Test reload level, outputs and skip routes", func(t *testing.T) {
+		appLogger := zlogger.GetAppLogger().Named("orders")
+		appLogger.Debug("debug before reload")
+		appLogger.Info("info before reload")
+		ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		writeSettings(fmt.Sprintf("serviceName: reload\nloggerType: json\nlevel: debug\noutputs: [\"rotate://%s\"]\nskipRoutes: [/health]\n", secondLog))
+		assert.Equal(t, watcher.Reload(), nil)
+
+		appLogger.Debug("debug after reload")
+		ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health?probe=1", nil))
+
+		assert.Equal(t, strings.Contains(readLog(firstLog), "info before reload"), true)
+		assert.Equal(t, strings.Contains(readLog(firstLog), "debug before reload"), false)
+		assert.Equal(t, strings.Contains(readLog(firstLog), "/health"), true)
+		assert.Equal(t, strings.Contains(readLog(secondLog), "debug after reload"), true)
+		assert.Equal(t, strings.Contains(readLog(secondLog), "/health"), false)
+	})
+
+	t.Run("Test invalid file keeps the loggers", func(t *testing.T) {
+		writeSettings("serviceName: reload\nloggerType: json\nlevel: loud\n")
+		assert.NotEqual(t, watcher.Reload(), nil)
+
+		zlogger.GetAppLogger().Debug("debug after bad reload")
+		assert.Equal(t, strings.Contains(readLog(secondLog), "debug after bad reload"), true)
+	})
+}
+
+func TestReloadClosesOutputs(t *testing.T) {
+	skipWithoutProcFD(t)
+	dir := t.TempDir()
+
+	settings := zlogger.Settings{ServiceName: "outputs", LoggerType: "json", Level: "info",
+		Outputs: []string{filepath.Join(dir, "first.log")}}
+	assert.Equal(t, zlogger.SetupLoggerFromSettings(settings, nil), nil)
+	opened := openFiles(dir)
+
+	for i := 0; i < 5; i++ {
+		settings.Outputs = []string{filepath.Join(dir, fmt.Sprintf("reload-%d.log", i))}
+		assert.Equal(t, zlogger.ApplySettings(settings), nil)
+	}
+	zlogger.GetAppLogger().Info("after reload")
+	assert.Equal(t, openFiles(dir), opened)
+
+	content, _ := os.ReadFile(filepath.Join(dir, "reload-4.log"))
+	assert.Equal(t, strings.Contains(string(content), "after reload"), true)
+
+	// the replaced set is not reloaded anymore, its loggers keep their outputs
+	assert.Equal(t, zlogger.SetupLoggerFromSettings(settings, nil), nil)
+	settings.Outputs = []string{filepath.Join(dir, "last.log")}
+	assert.Equal(t, zlogger.ApplySettings(settings), nil)
+	assert.Equal(t, openFiles(filepath.Join(dir, "reload-4.log")), opened)
+	assert.Equal(t, openFiles(filepath.Join(dir, "last.log")), opened)
+}
+
+func TestReloadKeepsErrorOutputs(t *testing.T) {
+	skipWithoutProcFD(t)
+	dir := t.TempDir()
+	errorLog := filepath.Join(dir, "errors.log")
+	settings := zlogger.Settings{ServiceName: "errors", LoggerType: "json", Level: "info",
+		Outputs: []string{filepath.Join(dir, "first.log")}, ErrorOutputs: []string{errorLog}}
+	assert.Equal(t, zlogger.SetupLoggerFromSettings(settings, nil), nil)
+	opened := openFiles(errorLog)
+
+	settings.Outputs = []string{filepath.Join(dir, "second.log")}
+	assert.Equal(t, zlogger.ApplySettings(settings), nil)
+	assert.Equal(t, openFiles(errorLog), opened)
+
+	settings.ErrorOutputs = []string{filepath.Join(dir, "other-errors.log")}
+	var configErr *zlogger.ConfigError
+	assert.Equal(t, errors.As(zlogger.ApplySettings(settings), &configErr), true)
+	assert.Equal(t, openFiles(errorLog), opened)
+}
+
+func TestReloadKeepsConcurrentEntries(t *testing.T) {
+	dir := t.TempDir()
+	outputs := []string{filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")}
+	settings := zlogger.Settings{ServiceName: "concurrent", LoggerType: "json", Level: "info", Outputs: outputs[:1]}
+	assert.Equal(t, zlogger.SetupLoggerFromSettings(settings, nil), nil)
+
+	const writers, entries = 8, 5000
+	appLogger := zlogger.GetAppLogger().Named("writer")
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < entries; j++ {
+				appLogger.Info("concurrent entry")
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for i := 1; ; i++ {
+		select {
+		case <-done:
+		default:
+			settings.Outputs = outputs[i%2 : i%2+1]
+			assert.Equal(t, zlogger.ApplySettings(settings), nil)
+			continue
+		}
+		break
+	}
+	appLogger.Sync()
+
+	count := 0
+	for _, output := range outputs {
+		content, _ := os.ReadFile(output)
+		count += strings.Count(string(content), "concurrent entry")
+	}
+	assert.Equal(t, count, writers*entries)
+}
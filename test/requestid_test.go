@@ -0,0 +1,98 @@
+package zlogger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Run("Test generators", func(t *testing.T) {
+		assert.MatchRegex(t, zlogger.NewRequestID(zlogger.UUIDV4), `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+		assert.MatchRegex(t, zlogger.NewRequestID(zlogger.ULID), `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+		assert.MatchRegex(t, zlogger.NewRequestID(zlogger.KSUID), `^[0-9A-Za-z]{27}$`)
+
+		first := zlogger.NewRequestID(zlogger.ULID)
+		time.Sleep(2 * time.Millisecond)
+		assert.Equal(t, first < zlogger.NewRequestID(zlogger.ULID), true)
+		assert.NotEqual(t, zlogger.NewRequestID(zlogger.KSUID), zlogger.NewRequestID(zlogger.KSUID))
+	})
+
+	t.Run("Test id is generated, echoed and logged", func(t *testing.T) {
+		loggerConfig, filename := newFileLoggerConfig(t, "requestid", zapcore.InfoLevel)
+		appLogger := zlogger.MustNewAppLogger(loggerConfig)
+
+		ginEng := gin.New()
+		ginEng.Use(
+			zlogger.RequestID(zlogger.RequestIDConfig{Header: "X-Correlation-ID", Generator: zlogger.ULID}),
+			gin.LoggerWithConfig(zlogger.MustNewGinLoggerConfig(loggerConfig, nil)),
+			zlogger.RequestLogger(appLogger),
+		)
+		ginEng.GET("/ping", func(c *gin.Context) {
+			zlogger.FromGinContext(c).Info("from request logger")
+			appLogger.InfoCtx(c.Request.Context(), "from ctx")
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		ginEng.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		requestID := w.Header().Get("X-Correlation-ID")
+		assert.MatchRegex(t, requestID, `^[0-9A-Z]{26}$`)
+
+		logged := 0
+		for _, entry := range readJSONEntries(t, filename) {
+			// lib entries and the gin route list are logged outside the request
+			if entry["loggerName"] == "lib" || entry["statusCode"] == nil && entry["message"] == nil {
+				continue
+			}
+			assert.Equal(t, entry["requestId"], requestID)
+			logged++
+		}
+		assert.Equal(t, logged, 3)
+	})
+
+	t.Run("Test incoming id is reused unless invalid", func(t *testing.T) {
+		ginEng := gin.New()
+		ginEng.Use(zlogger.RequestID(zlogger.RequestIDConfig{}))
+		ginEng.GET("/ping", func(c *gin.Context) {
+			c.String(http.StatusOK, zlogger.RequestIDFromContext(c))
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.Header.Set(zlogger.REQUEST_ID_HEADER, "upstream-1")
+		w := httptest.NewRecorder()
+		ginEng.ServeHTTP(w, req)
+		assert.Equal(t, w.Header().Get(zlogger.REQUEST_ID_HEADER), "upstream-1")
+		assert.Equal(t, w.Body.String(), "upstream-1")
+
+		req.Header.Set(zlogger.REQUEST_ID_HEADER, "forged\nline")
+		w = httptest.NewRecorder()
+		ginEng.ServeHTTP(w, req)
+		assert.Equal(t, regexp.MustCompile(`^[0-9a-f-]{36}$`).MatchString(w.Body.String()), true)
+	})
+
+	t.Run("Test gorm entries carry the id", func(t *testing.T) {
+		core, recorded := observer.New(zapcore.DebugLevel)
+		gormLogger := zlogger.GormLogger{
+			ZapLogger: zap.New(core),
+			LogLevel:  gormlogger.Info,
+		}
+		ctx := zlogger.ContextWithRequestID(context.Background(), "req-gorm")
+		gormLogger.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, nil)
+
+		assert.Equal(t, len(recorded.All()), 1)
+		assert.Equal(t, recorded.All()[0].ContextMap()["requestId"], "req-gorm")
+		assert.Equal(t, zlogger.RequestIDFromContext(ctx), "req-gorm")
+	})
+}
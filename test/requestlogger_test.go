@@ -0,0 +1,83 @@
+package zlogger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRequestLogger(t *testing.T) {
+	t.Run("Test request logger is stored in gin and request contexts", func(t *testing.T) {
+		appLogger, recorded := zlogger.NewAppLoggerForTest()
+		ginEng := gin.New()
+		ginEng.Use(zlogger.RequestLogger(appLogger))
+
+		logFromService := func(ctx context.Context) {
+			zlogger.FromContext(ctx).Info("from service")
+		}
+		ginEng.GET("/orders/:id", func(c *gin.Context) {
+			zlogger.FromGinContext(c).Info("from handler")
+			logFromService(c.Request.Context())
+			logFromService(c)
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/42", nil)
+		req.Header.Set(zlogger.REQUEST_ID_HEADER, "req-42")
+		req.Header.Set("User-Agent", "zlogger-test")
+		ginEng.ServeHTTP(httptest.NewRecorder(), req)
+
+		entries := recorded.All()
+		assert.Equal(t, len(entries), 3)
+		for _, entry := range entries {
+			fields := entry.ContextMap()
+			assert.Equal(t, fields["requestId"], "req-42")
+			assert.Equal(t, fields["requestMethod"], "GET")
+			assert.Equal(t, fields["route"], "/orders/:id")
+			assert.Equal(t, fields["clientIP"], "192.0.2.1")
+			assert.Equal(t, fields["userAgent"], "zlogger-test")
+		}
+	})
+
+	t.Run("Test ctx fields are not repeated by the request logger", func(t *testing.T) {
+		appLogger, recorded := zlogger.NewAppLoggerForTest()
+		ginEng := gin.New()
+		ginEng.Use(zlogger.RequestID(zlogger.RequestIDConfig{}), zlogger.RequestLogger(appLogger))
+		ginEng.GET("/ping", func(c *gin.Context) {
+			ctx := c.Request.Context()
+			zlogger.FromContext(ctx).InfoCtx(ctx, "from ctx")
+			zlogger.FromContext(ctx).Named("db").InfoCtxf(ctx, "from %s", "named")
+			c.Status(http.StatusOK)
+		})
+		ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+		entries := recorded.All()
+		assert.Equal(t, len(entries), 2)
+		for _, entry := range entries {
+			assert.Equal(t, countFields(entry.Context, "requestId"), 1)
+		}
+	})
+
+	t.Run("Test fallback to the default app logger", func(t *testing.T) {
+		assert.Equal(t, zlogger.FromContext(context.Background()), zlogger.GetAppLogger())
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		assert.Equal(t, zlogger.FromGinContext(c), zlogger.GetAppLogger())
+	})
+}
+
+func countFields(fields []zapcore.Field, key string) int {
+	count := 0
+	for _, field := range fields {
+		if field.Key == key {
+			count++
+		}
+	}
+	return count
+}
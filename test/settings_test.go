@@ -0,0 +1,102 @@
+package zlogger_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+func writeSettingsFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSettings(t *testing.T) {
+	t.Run("Test load yaml file", func(t *testing.T) {
+		path := writeSettingsFile(t, "zlogger.yaml", `
+serviceName: payments
+loggerType: json
+level: warn
+levelOverrides:
+  payments.db: debug
+outputs: [stdout]
+skipRoutes: [/health]
+sampling: {initial: 100, thereafter: 10}
+gorm: {slowThreshold: 200ms, logLevel: error}
+`)
+		settings, err := zlogger.LoadSettingsFromFile(path)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, settings.SkipRoutes, []string{"/health"})
+
+		loggerConfig, err := settings.LoggerConfig()
+		assert.Equal(t, err, nil)
+		assert.Equal(t, loggerConfig.GetLoggerName(), "payments")
+		assert.Equal(t, loggerConfig.GetLoggerType(), zlogger.JSON_LOGGER)
+		assert.Equal(t, loggerConfig.GetLoggerLevel(), zapcore.WarnLevel)
+		assert.Equal(t, loggerConfig.GetZapConfig().OutputPaths, []string{"stdout"})
+		assert.Equal(t, loggerConfig.GetZapConfig().Sampling.Initial, 100)
+
+		level, _ := loggerConfig.GetLevelOverrides().Match("payments.db")
+		assert.Equal(t, level, zapcore.DebugLevel)
+	})
+
+	t.Run("Test load json file", func(t *testing.T) {
+		path := writeSettingsFile(t, "zlogger.json", `{"serviceName": "payments", "gorm": {"slowThreshold": "1s"}}`)
+		settings, err := zlogger.LoadSettingsFromFile(path)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, settings.Gorm.SlowThreshold, "1s")
+	})
+
+	t.Run("Test unknown key", func(t *testing.T) {
+		path := writeSettingsFile(t, "zlogger.json", `{"serviceName": "payments", "lvl": "info"}`)
+		_, err := zlogger.LoadSettingsFromFile(path)
+		assert.MatchRegex(t, err.Error(), `"lvl"`)
+	})
+
+	t.Run("Test invalid value names the key", func(t *testing.T) {
+		path := writeSettingsFile(t, "zlogger.yaml", "gorm:\n  slowThreshold: fast\n")
+		_, err := zlogger.LoadSettingsFromFile(path)
+		var settingsErr *zlogger.SettingsError
+		assert.Equal(t, errors.As(err, &settingsErr), true)
+		assert.Equal(t, settingsErr.Key, "gorm.slowThreshold")
+	})
+
+	t.Run("Test env on top of file", func(t *testing.T) {
+		path := writeSettingsFile(t, "zlogger.yaml", "serviceName: payments\nlevel: warn\n")
+		t.Setenv(zlogger.ENV_CONFIG_FILE, path)
+		t.Setenv(zlogger.ENV_LOGGER_TYPE, "json")
+		t.Setenv(zlogger.ENV_LEVEL_OVERRIDES, "payments.db=debug, payments.api=error")
+		t.Setenv(zlogger.ENV_GORM_SLOW_THRESHOLD, "250ms")
+
+		settings, err := zlogger.LoadSettings()
+		assert.Equal(t, err, nil)
+		assert.Equal(t, settings.ServiceName, "payments")
+		assert.Equal(t, settings.Level, "warn")
+		assert.Equal(t, settings.LevelOverrides["payments.api"], "error")
+		slowThreshold, _ := time.ParseDuration(settings.Gorm.SlowThreshold)
+		assert.Equal(t, slowThreshold, 250*time.Millisecond)
+	})
+
+	t.Run("Test invalid env names the env var", func(t *testing.T) {
+		t.Setenv(zlogger.ENV_LEVEL, "loud")
+		_, err := zlogger.LoadSettingsFromEnv()
+		var settingsErr *zlogger.SettingsError
+		assert.Equal(t, errors.As(err, &settingsErr), true)
+		assert.Equal(t, settingsErr.Key, zlogger.ENV_LEVEL)
+
+		t.Setenv(zlogger.ENV_LEVEL, "info")
+		t.Setenv(zlogger.ENV_SAMPLING_INITIAL, "many")
+		_, err = zlogger.LoadSettingsFromEnv()
+		assert.Equal(t, errors.As(err, &settingsErr), true)
+		assert.Equal(t, settingsErr.Key, zlogger.ENV_SAMPLING_INITIAL)
+	})
+}
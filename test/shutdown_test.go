@@ -0,0 +1,79 @@
+package zlogger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+// Shutdown forgets every logger of the package, the default set is set up
+// again like on package init, so the tests running after it can reload it
+func shutdown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Equal(t, zlogger.Shutdown(ctx), nil)
+	t.Cleanup(func() {
+		zlogger.MustSetupLoggerWithConfig("default", zlogger.DEBUG_LOGGER, nil, nil)
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	t.Run("Test shutdown flushes async loggers", func(t *testing.T) {
+		appLogger, lines := newAsyncTestLogger(t, zlogger.AsyncConfig{FlushInterval: time.Hour, BatchSize: 1000})
+		for i := 0; i < 5; i++ {
+			appLogger.Info("async entry")
+		}
+		assert.Equal(t, len(lines()), 0)
+
+		shutdown(t)
+		assert.Equal(t, len(lines()), 5)
+
+		// the outputs are closed, logging after shutdown is dropped
+		appLogger.Info("async entry")
+		assert.Equal(t, len(lines()), 5)
+	})
+
+	t.Run("Test shutdown closes file outputs", func(t *testing.T) {
+		skipWithoutProcFD(t)
+		filename := filepath.Join(t.TempDir(), "plain.log")
+		appLogger := zlogger.MustNewAppLogger(zlogger.NewLoggerConfig("plain", zlogger.JSON_LOGGER, zapcore.InfoLevel,
+			zlogger.WithOutputPaths(filename)))
+		appLogger.Info("plain entry")
+		assert.NotEqual(t, openFiles(filename), 0)
+
+		shutdown(t)
+		assert.Equal(t, openFiles(filename), 0)
+	})
+
+	t.Run("Test shutdown reports suppressed gin requests", func(t *testing.T) {
+		loggerConfig, filename := newFileLoggerConfig(t, "shutdownsampling", zapcore.InfoLevel)
+
+		ginEng := gin.New()
+		ginEng.Use(zlogger.MustNewGinLogger(loggerConfig, nil,
+			zlogger.WithRequestSampling(zlogger.RequestSampling{First: 1, Every: 10, ReportInterval: time.Hour}),
+		))
+		ginEng.GET("/hits", func(c *gin.Context) { c.Status(http.StatusOK) })
+		for i := 0; i < 3; i++ {
+			ginEng.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/hits", nil))
+		}
+
+		shutdown(t)
+
+		var reports []map[string]interface{}
+		for _, entry := range readJSONEntries(t, filename) {
+			if entry["suppressed"] != nil {
+				reports = append(reports, entry)
+			}
+		}
+		assert.Equal(t, len(reports), 1)
+		assert.Equal(t, reports[0]["suppressed"], float64(2))
+	})
+}
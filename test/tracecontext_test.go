@@ -0,0 +1,122 @@
+package zlogger_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/assert/v2"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID      = "00f067aa0ba902b7"
+	testTraceparent = "00-" + testTraceID + "-" + testSpanID + "-01"
+)
+
+func TestTraceContext(t *testing.T) {
+	t.Run("Test traceparent parsing", func(t *testing.T) {
+		spanContext := zlogger.ParseTraceParent(testTraceparent, "vendor=value")
+		assert.Equal(t, spanContext.IsValid(), true)
+		assert.Equal(t, spanContext.IsRemote(), true)
+		assert.Equal(t, spanContext.TraceID().String(), testTraceID)
+		assert.Equal(t, spanContext.SpanID().String(), testSpanID)
+		assert.Equal(t, spanContext.IsSampled(), true)
+		assert.Equal(t, spanContext.TraceState().Get("vendor"), "value")
+
+		for _, traceparent := range []string{
+			"",
+			"ff-" + testTraceID + "-" + testSpanID + "-01",
+			"00-" + testTraceID + "-" + testSpanID + "-01-extra",
+			"00-00000000000000000000000000000000-" + testSpanID + "-01",
+			"00-" + testTraceID + "-zzzzzzzzzzzzzzzz-01",
+		} {
+			assert.Equal(t, zlogger.ParseTraceParent(traceparent, "").IsValid(), false)
+		}
+		assert.Equal(t, zlogger.ParseTraceParent("01-"+testTraceID+"-"+testSpanID+"-00-extra", "").IsValid(), true)
+	})
+
+	t.Run("Test gin, request and ctx entries carry the trace ids", func(t *testing.T) {
+		loggerConfig, filename := newFileLoggerConfig(t, "trace", zapcore.InfoLevel)
+		appLogger := zlogger.MustNewAppLogger(loggerConfig)
+
+		ginEng := gin.New()
+		ginEng.Use(
+			zlogger.TraceContext(),
+			gin.LoggerWithConfig(zlogger.MustNewGinLoggerConfig(loggerConfig, nil)),
+			zlogger.RequestLogger(appLogger),
+		)
+		ginEng.GET("/traced", func(c *gin.Context) {
+			zlogger.FromGinContext(c).Info("from request logger")
+			appLogger.InfoCtx(c.Request.Context(), "from ctx")
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/traced", nil)
+		req.Header.Set(zlogger.TRACEPARENT_HEADER, testTraceparent)
+		ginEng.ServeHTTP(httptest.NewRecorder(), req)
+
+		logged := 0
+		for _, entry := range readJSONEntries(t, filename) {
+			if entry["loggerName"] == "lib" || entry["statusCode"] == nil && entry["message"] == nil {
+				continue
+			}
+			assert.Equal(t, entry["trace_id"], testTraceID)
+			assert.Equal(t, entry["span_id"], testSpanID)
+			assert.Equal(t, entry["trace_flags"], "01")
+			logged++
+		}
+		assert.Equal(t, logged, 3)
+	})
+
+	t.Run("Test request logger repeats no trace field", func(t *testing.T) {
+		appLogger, recorded := zlogger.NewAppLoggerForTest()
+		ginEng := gin.New()
+		ginEng.Use(zlogger.TraceContext(), zlogger.RequestLogger(appLogger))
+		ginEng.GET("/traced", func(c *gin.Context) {
+			ctx := c.Request.Context()
+			zlogger.FromContext(ctx).InfoCtx(ctx, "from ctx")
+			zlogger.FromContext(ctx).With(zap.String("orderId", "7")).WarnCtxf(ctx, "from %s", "child")
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/traced", nil)
+		req.Header.Set(zlogger.TRACEPARENT_HEADER, testTraceparent)
+		ginEng.ServeHTTP(httptest.NewRecorder(), req)
+
+		entries := recorded.All()
+		assert.Equal(t, len(entries), 2)
+		for _, entry := range entries {
+			for _, key := range []string{"trace_id", "span_id", "trace_flags"} {
+				assert.Equal(t, countFields(entry.Context, key), 1)
+			}
+			assert.Equal(t, entry.ContextMap()["trace_id"], testTraceID)
+		}
+	})
+
+	t.Run("Test active span is used by the gorm logger", func(t *testing.T) {
+		spanContext := zlogger.ParseTraceParent(testTraceparent, "")
+		ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+		core, recorded := observer.New(zapcore.DebugLevel)
+		gormLogger := zlogger.GormLogger{
+			ZapLogger: zap.New(core),
+			LogLevel:  gormlogger.Info,
+		}
+		gormLogger.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, nil)
+		fields := recorded.All()[0].ContextMap()
+		assert.Equal(t, fields["trace_id"], testTraceID)
+		assert.Equal(t, fields["span_id"], testSpanID)
+
+		assert.Equal(t, len(zlogger.FieldsFromContext(context.Background())), 0)
+	})
+}
@@ -4,16 +4,35 @@ import (
 	"testing"
 
 	"github.com/Zbyteio/zlogger-lib"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 func TestAppLogger(t *testing.T)  {
   // var ZBlocksAppDebugLogger zlogger.AppLogger = zlogger.NewAppLogger(zlogger.NewLoggerConfig("applogger", zlogger.DEBUG_LOGGER, zapcore.DebugLevel))
-  var ZBlocksAppReleaseLogger zlogger.AppLogger = zlogger.NewAppLogger(zlogger.NewLoggerConfig("applogger", zlogger.DEBUG_LOGGER, zapcore.InfoLevel))
+  var ZBlocksAppReleaseLogger zlogger.AppLogger = zlogger.MustNewAppLogger(zlogger.NewLoggerConfig("applogger", zlogger.DEBUG_LOGGER, zapcore.InfoLevel))
 
 
   t.Run("Test App logger", func(t *testing.T) {
     //ZBlocksAppDebugLogger.Debugf("%s", "success print debug via applogger[DEBUG]")
     ZBlocksAppReleaseLogger.Debugf("%s", "success print debug via applogger[RELEASE]")
   })
-}
\ No newline at end of file
+}
+func TestAppLoggerChildren(t *testing.T) {
+  t.Run("Test With and Named", func(t *testing.T) {
+    appLogger, recorded := zlogger.NewAppLoggerForTest()
+    named := zlogger.CreateLoggerName("svc_name", "pkg_name")
+
+    jobLogger := appLogger.Named(named).Named("job").With(zap.String("jobId", "job-1"))
+    jobLogger.Infof("%s started", "job")
+    appLogger.Info("parent message")
+
+    entries := recorded.All()
+    assert.Equal(t, entries[0].LoggerName, "svc_name.pkg_name.job")
+    assert.Equal(t, entries[0].Message, "job started")
+    assert.Equal(t, entries[0].ContextMap()["jobId"], "job-1")
+    assert.Equal(t, entries[1].LoggerName, "")
+    assert.Equal(t, len(entries[1].Context), 0)
+  })
+}
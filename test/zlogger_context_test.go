@@ -0,0 +1,48 @@
+package zlogger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Zbyteio/zlogger-lib"
+	"github.com/go-playground/assert/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type userIDKey struct{}
+
+func TestContextLogger(t *testing.T) {
+	zlogger.RegisterContextExtractor(func(ctx context.Context) []zapcore.Field {
+		if userID, ok := ctx.Value(userIDKey{}).(string); ok {
+			return []zapcore.Field{zap.String("userId", userID)}
+		}
+		return nil
+	})
+
+	t.Run("Test ctx fields are attached", func(t *testing.T) {
+		appLogger, recorded := zlogger.NewAppLoggerForTest()
+
+		ctx := zlogger.ContextWithFields(context.Background(), zap.String("requestId", "req-1"))
+		ctx = context.WithValue(ctx, userIDKey{}, "user-1")
+		appLogger.InfoCtx(ctx, "ctx message", zap.Int("attempt", 2))
+		appLogger.WarnCtxf(ctx, "ctx %s", "formatted")
+
+		entries := recorded.All()
+		assert.Equal(t, len(entries), 2)
+
+		fields := entries[0].ContextMap()
+		assert.Equal(t, fields["requestId"], "req-1")
+		assert.Equal(t, fields["userId"], "user-1")
+		assert.Equal(t, fields["attempt"], int64(2))
+
+		assert.Equal(t, entries[1].Message, "ctx formatted")
+		assert.Equal(t, entries[1].ContextMap()["requestId"], "req-1")
+	})
+
+	t.Run("Test ctx without fields", func(t *testing.T) {
+		appLogger, recorded := zlogger.NewAppLoggerForTest()
+		appLogger.ErrorCtx(context.Background(), "plain message")
+		assert.Equal(t, len(recorded.All()[0].Context), 0)
+	})
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func TestGinLogger(t *testing.T) {
-	var ZBlocksGinDebugLogger gin.LoggerConfig = zlogger.NewGinLoggerConfig(
+	var ZBlocksGinDebugLogger gin.LoggerConfig = zlogger.MustNewGinLoggerConfig(
 		zlogger.NewLoggerConfig(
 			"ginlogger",
 			zlogger.DEBUG_LOGGER,
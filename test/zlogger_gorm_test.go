@@ -21,7 +21,7 @@ func TestGormLogger(t *testing.T) {
 		}
 		
 		gormdebugConf := zlogger.NewLoggerConfig("gormlogger_v2", zlogger.DEBUG_LOGGER, zapcore.DebugLevel)
-		zlogger.SetupGormLogger(db, gormdebugConf)    
+		zlogger.MustSetupGormLogger(db, gormdebugConf)
 		type User struct {
 			gorm.Model
 			Email string `json:"email"`
@@ -0,0 +1,104 @@
+package zlogger
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+/* DOCS -
+W3C trace context and OpenTelemetry spans
+the span of a context.Context (started by otel, or remote from the
+traceparent/tracestate headers) adds trace_id, span_id and trace_flags
+to the *Ctx app methods, the gorm logger, the request logger and the gin entry
+*/
+
+const (
+	TRACEPARENT_HEADER string = "traceparent"
+	TRACESTATE_HEADER  string = "tracestate"
+)
+
+// gin.Context key of the trace.SpanContext of the request
+const SPAN_CONTEXT_KEY string = "zlogger.spanContext"
+
+// TraceContext returns a gin middleware that parses the traceparent and
+// tracestate headers into a remote span of the request context, unless an
+// otel middleware running before it already started a span.
+// Use it before RequestLogger and the gin logger.
+func TraceContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		spanContext := trace.SpanContextFromContext(ctx)
+		if !spanContext.IsValid() {
+			spanContext = ParseTraceParent(c.GetHeader(TRACEPARENT_HEADER), c.GetHeader(TRACESTATE_HEADER))
+			if spanContext.IsValid() {
+				c.Request = c.Request.WithContext(trace.ContextWithRemoteSpanContext(ctx, spanContext))
+			}
+		}
+		if spanContext.IsValid() {
+			c.Set(SPAN_CONTEXT_KEY, spanContext)
+		}
+		c.Next()
+	}
+}
+
+// ParseTraceParent returns the remote span context described by the W3C
+// traceparent and tracestate headers, an invalid one if traceparent is malformed.
+func ParseTraceParent(traceparent, tracestate string) trace.SpanContext {
+	// version-traceid-spanid-flags, future versions may append fields
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 {
+		return trace.SpanContext{}
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || version == "ff" || version == "00" && len(parts) != 4 {
+		return trace.SpanContext{}
+	}
+	if _, err := hex.DecodeString(version); err != nil {
+		return trace.SpanContext{}
+	}
+	if len(traceID) != 32 || len(spanID) != 16 || len(flags) != 2 {
+		return trace.SpanContext{}
+	}
+
+	var spanContextConfig trace.SpanContextConfig
+	var err error
+	if spanContextConfig.TraceID, err = trace.TraceIDFromHex(traceID); err != nil {
+		return trace.SpanContext{}
+	}
+	if spanContextConfig.SpanID, err = trace.SpanIDFromHex(spanID); err != nil {
+		return trace.SpanContext{}
+	}
+	flagBytes, err := hex.DecodeString(flags)
+	if err != nil {
+		return trace.SpanContext{}
+	}
+	spanContextConfig.TraceFlags = trace.TraceFlags(flagBytes[0]) & trace.FlagsSampled
+	// an invalid tracestate is dropped, the parent is still used
+	spanContextConfig.TraceState, _ = trace.ParseTraceState(tracestate)
+	spanContextConfig.Remote = true
+	return trace.NewSpanContext(spanContextConfig)
+}
+
+func traceFields(ctx context.Context) []zapcore.Field {
+	if ctx == nil {
+		return nil
+	}
+	return spanContextFields(trace.SpanContextFromContext(ctx))
+}
+
+func spanContextFields(spanContext trace.SpanContext) []zapcore.Field {
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []zapcore.Field{
+		zap.String("trace_id", spanContext.TraceID().String()),
+		zap.String("span_id", spanContext.SpanID().String()),
+		zap.String("trace_flags", spanContext.TraceFlags().String()),
+	}
+}
@@ -2,9 +2,14 @@ package zlogger
 
 import (
 	"fmt"
-	"log"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 func CreateLoggerName(serviceName string, packageName string, rest ...string) string {
@@ -17,17 +22,150 @@ func CreateLoggerName(serviceName string, packageName string, rest ...string) st
   return loggerName
 }
 
-func generateZapLogger(zapconfig *zap.Config,loggerName string)(*zap.Logger) {
-  var _logger *zap.Logger
-  var err error
-	_logger, err = zapconfig.Build(zap.AddCallerSkip(1))
-	defer _logger.Sync()
+func generateZapLogger(loggerConfig *LoggerConfig,loggerName string)(*zap.Logger, error) {
+  var zapconfig zap.Config = loggerConfig.config
+  var queue *asyncQueue
+
+  if loggerConfig.async != nil {
+    queue = newAsyncQueue(*loggerConfig.async)
+  }
+  core, closeSinks, err := newZapCore(zapconfig, queue)
+  if err != nil {
+    if queue != nil {
+      queue.close()
+    }
+    return nil, err
+  }
+  errSink, closeErrSink, err := zap.Open(zapconfig.ErrorOutputPaths...)
+  if err != nil {
+    closeSinks()
+    if queue != nil {
+      queue.close()
+    }
+    return nil, err
+  }
+  core, outputs := newOutputCore(core, closeSinks)
+  reloadable := newReloadableCore(buildCoreStack(loggerConfig, core))
+
+  _logger := zap.New(reloadable, append(zapOptions(zapconfig, errSink), zap.AddCallerSkip(1))...)
+  trackLogger(_logger, *loggerConfig, queue, reloadable, outputs, closeErrSink)
+  _logger = _logger.Named(loggerName)
+  return _logger, nil
+}
+
+// builds a new core for a running logger, used on reload
+// outputs are the outputs opened for it
+func rebuildZapCore(loggerConfig *LoggerConfig, queue *asyncQueue) (zapcore.Core, *coreOutputs, error) {
+	core, closeSinks, err := newZapCore(loggerConfig.config, queue)
 	if err != nil {
-		// zap logger unable to initialize
-		// use default logger to log this
-		log.Printf("ERROR :: %s", err.Error())
-		return nil
-	}
-	_logger = _logger.Named(loggerName)
-	return _logger
-}
\ No newline at end of file
+		return nil, nil, err
+	}
+	core, outputs := newOutputCore(core, closeSinks)
+	return buildCoreStack(loggerConfig, core), outputs, nil
+}
+
+// scheme of the outputs opened by newZapCore, handed to zap.Config.Build
+const openedSinkScheme = "zlogger-opened"
+
+var (
+	_openedSinkID       atomic.Uint64
+	_openedSinks        sync.Map
+	_openedSinkRegister sync.Once
+)
+
+// the outputs are closed by newZapCore's close func, not by zap
+type openedSink struct {
+	zapcore.WriteSyncer
+}
+
+func (openedSink) Close() error {
+	return nil
+}
+
+func newOpenedSink(sinkURL *url.URL) (zap.Sink, error) {
+	sink, ok := _openedSinks.Load(sinkURL.Host)
+	if !ok {
+		return nil, fmt.Errorf("no outputs opened for %q", sinkURL)
+	}
+	return openedSink{sink.(zapcore.WriteSyncer)}, nil
+}
+
+// the core zap.Config.Build would create, with the close func of the
+// outputs, so they can be closed when the core is swapped on reload
+// the encoder is built by zap, encoders added with zap.RegisterEncoder included
+// with a queue, the encoded entries are written in the background
+func newZapCore(zapconfig zap.Config, queue *asyncQueue) (zapcore.Core, func(), error) {
+	out, closeOutputs, err := zap.Open(zapconfig.OutputPaths...)
+	if err != nil {
+		return nil, nil, err
+	}
+	sink, closeSinks := out, closeOutputs
+	if queue != nil {
+		sink = newAsyncWriter(out, queue)
+		// the entries queued for these outputs are written before they are closed
+		closeSinks = func() {
+			queue.drain()
+			closeOutputs()
+		}
+	}
+	// registered on first use, the default loggers are built by an init func
+	_openedSinkRegister.Do(func() {
+		if err := zap.RegisterSink(openedSinkScheme, newOpenedSink); err != nil {
+			panic(err)
+		}
+	})
+	key := strconv.FormatUint(_openedSinkID.Add(1), 10)
+	_openedSinks.Store(key, sink)
+	defer _openedSinks.Delete(key)
+
+	// the error output and sampling are set up by the caller
+	zapconfig.OutputPaths = []string{openedSinkScheme + "://" + key}
+	zapconfig.ErrorOutputPaths = nil
+	zapconfig.Sampling = nil
+	var core zapcore.Core
+	_, err = zapconfig.Build(zap.WrapCore(func(built zapcore.Core) zapcore.Core {
+		core = built
+		return built
+	}))
+	if err != nil {
+		closeSinks()
+		return nil, nil, err
+	}
+	return core, closeSinks, nil
+}
+
+// the logger options of zap.Config.Build
+func zapOptions(zapconfig zap.Config, errSink zapcore.WriteSyncer) []zap.Option {
+	options := []zap.Option{zap.ErrorOutput(errSink)}
+	if zapconfig.Development {
+		options = append(options, zap.Development())
+	}
+	if !zapconfig.DisableCaller {
+		options = append(options, zap.AddCaller())
+	}
+	stackLevel := zap.ErrorLevel
+	if zapconfig.Development {
+		stackLevel = zap.WarnLevel
+	}
+	if !zapconfig.DisableStacktrace {
+		options = append(options, zap.AddStacktrace(stackLevel))
+	}
+	return options
+}
+
+// wraps the output core - level overrides -> sampler
+// the sampler also counts the entries enabled by an override
+// reloads are handled on top of this stack
+func buildCoreStack(loggerConfig *LoggerConfig, core zapcore.Core) zapcore.Core {
+	if loggerConfig.levelOverrides != nil {
+		core = newLevelOverrideCore(core, loggerConfig.levelOverrides)
+	}
+	if sampling := loggerConfig.config.Sampling; sampling != nil {
+		var samplerOptions []zapcore.SamplerOption
+		if sampling.Hook != nil {
+			samplerOptions = append(samplerOptions, zapcore.SamplerHook(sampling.Hook))
+		}
+		core = zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter, samplerOptions...)
+	}
+	return core
+}